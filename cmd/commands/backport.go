@@ -0,0 +1,447 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// BackportCmd cherry-picks merged fixes onto release and support branches.
+//
+// The fix is given as a flow branch (the commits made on it, not those it got from syncing
+// with its base), a commit (a merge commit stands for the commits it merged) or a range
+// `from..to`. For each target it will:
+//
+//  1. Fast-forward the target to origin and create `backport/<target>/<name>` from it
+//  2. Cherry-pick the commits with `-x`, so each one records the commit it came from
+//  3. Stop on conflicts so they can be resolved and resumed with `--continue` or dropped with `--abort`
+//  4. Finish the backport branch like `dflow finish`: merge it, or print the pull request
+//     to open when the target uses manual merges
+//
+// Example usage:
+//
+//	dflow backport bugfix/login-crash --to release/2.3.0,support/1.x
+//	dflow backport 4f2a9c1 --to 1.x
+//	dflow backport --continue
+//	dflow backport --abort
+var BackportCmd = &cobra.Command{
+	Use:   "backport <branch|commit|range> --to <targets>",
+	Short: "Cherry-pick a merged fix onto release and support branches",
+	Long: `Backport a fix onto other branches, such as ongoing releases and support branches.
+
+  The fix is a flow branch (the commits made on it, not those it got from 'dflow sync'),
+  a commit (a merge commit stands for the commits it merged) or a range such as
+  'v1.4.2..4f2a9c1'. For each target, a 'backport/<target>/<name>' branch is created from
+  it, the commits are cherry-picked with -x, and the branch is finished like 'dflow finish':
+  merged into the target, or pushed with the pull request to open when the target uses
+  manual merges.
+
+  Targets are branch names; support lines such as '1.x' are accepted for support/1.x.
+
+  Examples:
+    dflow backport bugfix/login-crash --to release/2.3.0,support/1.x
+    dflow backport 4f2a9c1 --to 1.x --push
+    dflow backport --continue
+    dflow backport --abort
+
+  On conflicts, resolve them, stage the files with 'git add' and run 'dflow backport --continue'
+  to finish this target and go on with the others, or 'dflow backport --abort' to drop the
+  backport branch and stop.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		targets, _ := cmd.Flags().GetStringSlice("to")
+		resume, _ := cmd.Flags().GetBool("continue")
+		abort, _ := cmd.Flags().GetBool("abort")
+		push, _ := cmd.Flags().GetBool("push")
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if abort {
+			abortBackport()
+			return nil
+		}
+		if resume {
+			continueBackport(cfg, push)
+			return nil
+		}
+
+		if len(args) == 0 || len(targets) == 0 {
+			_ = cmd.Help()
+			return nil
+		}
+
+		if err := validators.EnsureOnBranch(); err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+		if err := validators.EnsureNoOperationInProgress(); err != nil {
+			utils.Error(err.Error())
+			utils.Info("Use `dflow backport --continue` or `dflow backport --abort` if a backport stopped.")
+			return nil
+		}
+		if gitutils.IsDirty() {
+			utils.Error("You have uncommitted changes. Commit or stash them before backporting.")
+			return nil
+		}
+
+		commits, name, err := backportSource(cfg, args[0])
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		plan := backportPlan{Commits: commits, Name: name, Return: gitutils.CurrentBranch(), Push: push}
+		for _, target := range targets {
+			resolved, err := backportTarget(cfg, strings.TrimSpace(target))
+			if err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+			branch := utils.BackportBranch(cfg, resolved, name)
+			if valid, reason := validators.IsValidGitBranchName(branch); !valid {
+				utils.Error("Invalid branch name '%s': %s", branch, reason)
+				return nil
+			}
+			plan.Targets = append(plan.Targets, resolved)
+		}
+
+		utils.Info("Backporting %d commit(s) onto %s", len(commits), strings.Join(plan.Targets, ", "))
+		runBackport(cfg, plan)
+		return nil
+	}),
+}
+
+// backportPlan describes a backport of commits onto several targets.
+type backportPlan struct {
+	Commits []string // commits to cherry-pick, oldest first
+	Targets []string // branches still to backport onto
+	Name    string   // name of the backport branches, backport/<target>/<name>
+	Return  string   // branch to check out once every target is done
+	Push    bool     // push without asking
+}
+
+// Keys under `branch.<backport branch>` keeping the rest of a plan while its cherry-pick
+// is stopped on conflicts.
+const (
+	backportCommitsKey = "dflow-backport-commits"
+	backportTargetsKey = "dflow-backport-targets"
+	backportReturnKey  = "dflow-backport-return"
+)
+
+// runBackport backports the commits of plan onto each of its targets in turn. It stops at
+// the first target that needs the user, e.g. to resolve conflicts or after a failed finish.
+func runBackport(cfg *utils.Config, plan backportPlan) {
+	for len(plan.Targets) > 0 {
+		target := plan.Targets[0]
+		plan.Targets = plan.Targets[1:]
+		if !backportOnto(cfg, target, plan) {
+			if len(plan.Targets) > 0 {
+				utils.Info("Still to backport onto: %s", strings.Join(plan.Targets, ", "))
+			}
+			return
+		}
+	}
+
+	if plan.Return != "" && plan.Return != gitutils.CurrentBranch() && gitutils.LocalBranchExists(plan.Return) {
+		if err := gitutils.Checkout(plan.Return); err == nil {
+			utils.Info("Back on '%s'", plan.Return)
+		}
+	}
+}
+
+// backportOnto creates the backport branch of plan from the latest target, cherry-picks
+// the commits onto it and finishes it into target like `dflow finish`. It returns false
+// when the backport stopped, which is reported to the user.
+func backportOnto(cfg *utils.Config, target string, plan backportPlan) bool {
+	branch := utils.BackportBranch(cfg, target, plan.Name)
+	if gitutils.LocalBranchExists(branch) {
+		utils.Error("'%s' already exists. Finish or delete it first.", branch)
+		return false
+	}
+
+	if !updateBase(target) {
+		utils.Error("Reconcile '%s' with origin (see `dflow update`) before backporting onto it", target)
+		return false
+	}
+	if err := gitutils.CheckoutNewFrom(branch, target); err != nil {
+		utils.Error("Failed to create branch '%s' from '%s'", branch, target)
+		return false
+	}
+
+	meta := gitutils.BranchMeta{
+		Type:        utils.TypeBackport,
+		Base:        target,
+		Target:      target,
+		StartCommit: gitutils.HeadCommit(branch),
+		Author:      gitutils.CurrentAuthor(),
+		Created:     time.Now().Format(time.RFC3339),
+	}
+	if err := gitutils.SaveBranchMeta(branch, meta); err != nil {
+		utils.Warn("Could not record flow metadata: %v", err)
+	}
+	saveBackportPlan(branch, plan)
+
+	if err := gitutils.CherryPick(plan.Commits); err != nil {
+		if op := gitutils.OperationInProgress(); op != "" {
+			reportConflicts(op, "dflow backport")
+			return false
+		}
+		utils.Error("Failed to cherry-pick onto '%s': %v", branch, err)
+		return false
+	}
+	utils.Success("Cherry-picked %d commit(s) onto '%s'", len(plan.Commits), branch)
+
+	return finishBackport(branch, plan)
+}
+
+// finishBackport finishes a backport branch like `dflow finish` and reports whether it
+// succeeded. On failure, the rest of the plan stays recorded on the branch so that
+// `dflow backport --continue` can pick it up.
+func finishBackport(branch string, plan backportPlan) bool {
+	if finishBranch(branch, finishOptions{Push: plan.Push}) {
+		return true
+	}
+	if gitutils.LocalBranchExists(branch) {
+		utils.Info("Once it is fixed, run `dflow backport --continue` on '%s' to finish it and go on with the other targets.", branch)
+	}
+	return false
+}
+
+// continueBackport completes the cherry-pick of the current backport branch after its
+// conflicts were resolved, finishes it and goes on with the remaining targets.
+func continueBackport(cfg *utils.Config, push bool) {
+	branch := gitutils.CurrentBranch()
+	if branch == "" || gitutils.LoadBranchMeta(branch).Type != utils.TypeBackport {
+		utils.Info("There is no backport in progress on the current branch.")
+		return
+	}
+
+	if op := gitutils.OperationInProgress(); op != "" {
+		if err := gitutils.ContinueOperation(op); err != nil {
+			reportConflicts(op, "dflow backport")
+			utils.Info("If a commit is already on the target, drop it with `git cherry-pick --skip`.")
+			return
+		}
+		utils.Success("Completed the %s onto '%s'", op, branch)
+	}
+
+	plan := loadBackportPlan(branch)
+	plan.Push = push
+	if !finishBackport(branch, plan) {
+		if len(plan.Targets) > 0 {
+			utils.Info("Still to backport onto: %s", strings.Join(plan.Targets, ", "))
+		}
+		return
+	}
+	runBackport(cfg, plan)
+}
+
+// abortBackport drops the current backport branch, aborting its cherry-pick, and goes
+// back to the branch the backport was started from. Remaining targets are not backported.
+func abortBackport() {
+	branch := gitutils.CurrentBranch()
+	meta := gitutils.LoadBranchMeta(branch)
+	if branch == "" || meta.Type != utils.TypeBackport {
+		utils.Info("There is no backport in progress on the current branch.")
+		return
+	}
+
+	if op := gitutils.OperationInProgress(); op != "" {
+		if err := gitutils.AbortOperation(op); err != nil {
+			utils.Error("Failed to abort the %s: %v", op, err)
+			return
+		}
+	}
+
+	plan := loadBackportPlan(branch)
+	back := plan.Return
+	if back == "" || !gitutils.LocalBranchExists(back) {
+		back = meta.Target
+	}
+	if err := gitutils.Checkout(back); err != nil {
+		utils.Error("Could not checkout '%s'", back)
+		return
+	}
+	if err := gitutils.DeleteLocal(branch); err != nil {
+		utils.Error(err.Error())
+		return
+	}
+
+	utils.Warn("Backport onto '%s' aborted", meta.Target)
+	if len(plan.Targets) > 0 {
+		utils.Warn("Not backported onto: %s", strings.Join(plan.Targets, ", "))
+	}
+}
+
+// saveBackportPlan records what is left of plan on the backport branch.
+func saveBackportPlan(branch string, plan backportPlan) {
+	values := map[string]string{
+		backportCommitsKey: strings.Join(plan.Commits, " "),
+		backportTargetsKey: strings.Join(plan.Targets, " "),
+		backportReturnKey:  plan.Return,
+	}
+	for key, value := range values {
+		if err := gitutils.SetBranchConfig(branch, key, value); err != nil {
+			utils.Warn("Could not record the backport plan: %v", err)
+			return
+		}
+	}
+}
+
+// loadBackportPlan reads the plan recorded on a backport branch.
+func loadBackportPlan(branch string) backportPlan {
+	name := branch[strings.LastIndex(branch, "/")+1:]
+	return backportPlan{
+		Commits: strings.Fields(gitutils.GetBranchConfig(branch, backportCommitsKey)),
+		Targets: strings.Fields(gitutils.GetBranchConfig(branch, backportTargetsKey)),
+		Name:    name,
+		Return:  gitutils.GetBranchConfig(branch, backportReturnKey),
+	}
+}
+
+// backportSource returns the commits to backport for a flow branch, a commit or a range,
+// oldest first, with the name of the backport branches.
+func backportSource(cfg *utils.Config, ref string) ([]string, string, error) {
+	var commits []gitutils.Commit
+	var name string
+
+	switch {
+	case strings.Contains(ref, ".."):
+		from, to, _ := strings.Cut(ref, "..")
+		if !gitutils.RefExists(from+"^{commit}") || !gitutils.RefExists(to+"^{commit}") {
+			return nil, "", fmt.Errorf("'%s' is not a valid commit range", ref)
+		}
+		commits = gitutils.CommitsBetween(from, to)
+		name = shortHash(gitutils.HeadCommit(to))
+
+	case gitutils.LocalBranchExists(ref):
+		meta := gitutils.ResolveBranchMeta(cfg, ref)
+		if meta.StartCommit == "" && meta.Base == "" {
+			return nil, "", fmt.Errorf("'%s' has no recorded base or start commit. Pass its commits as a range instead, e.g. <first>^..%s", ref, ref)
+		}
+		commits = gitutils.BranchCommits(ref, meta.Base, meta.StartCommit)
+		name = strings.ReplaceAll(strings.TrimPrefix(ref, utils.PrefixForType(cfg, meta.Type)), "/", "-")
+
+	case gitutils.RefExists(ref + "^{commit}"):
+		hash := gitutils.HeadCommit(ref)
+		if parents := gitutils.CommitParents(hash); len(parents) > 1 {
+			// a merge commit stands for the commits it brought in
+			commits = gitutils.CommitsBetween(parents[0], parents[1])
+		} else {
+			commits = []gitutils.Commit{{Hash: hash}}
+		}
+		name = shortHash(hash)
+
+	default:
+		return nil, "", fmt.Errorf("'%s' is not a local branch, a commit or a commit range", ref)
+	}
+
+	if len(commits) == 0 {
+		return nil, "", fmt.Errorf("'%s' has no commits to backport", ref)
+	}
+	hashes := make([]string, len(commits))
+	for i, commit := range commits {
+		hashes[i] = commit.Hash
+	}
+	return hashes, name, nil
+}
+
+// backportTarget resolves a target of `--to`: an existing branch, or a support line.
+func backportTarget(cfg *utils.Config, target string) (string, error) {
+	exists := func(branch string) bool {
+		return gitutils.LocalBranchExists(branch) || gitutils.RefExists("refs/remotes/origin/"+branch)
+	}
+	if exists(target) {
+		return target, nil
+	}
+	if support := utils.SupportBranch(cfg, target); exists(support) {
+		return support, nil
+	}
+	return "", fmt.Errorf("backport target '%s' does not exist locally or on origin", target)
+}
+
+// shortHash abbreviates a commit hash for branch names.
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
+
+// portableCommits returns the commits of a hotfix finished into a support branch that can
+// be ported to main: every commit made on it except its version file bump, leaving out the
+// commits it got from syncing with the support branch.
+func portableCommits(cfg *utils.Config, branch string, meta gitutils.BranchMeta) []string {
+	if meta.StartCommit == "" && meta.Base == "" {
+		return nil
+	}
+
+	bump := ""
+	if tag, ok := versioning.ReleaseTag(cfg, branch, utils.TypeHotfix); ok && len(cfg.VersionFiles) > 0 {
+		bump = utils.GetBumpMessage(cfg, strings.TrimPrefix(tag, utils.GetTagPrefix(cfg)), branch, utils.TypeHotfix)
+	}
+
+	var commits []string
+	for _, commit := range gitutils.BranchCommits(branch, meta.Base, meta.StartCommit) {
+		if bump != "" && commit.Subject == bump {
+			continue
+		}
+		commits = append(commits, commit.Hash)
+	}
+	return commits
+}
+
+// offerPortToMain asks whether the commits of a hotfix finished into a support branch
+// should be backported to the main branch as well, and backports them if so.
+func offerPortToMain(cfg *utils.Config, branch string, commits []string, push bool) {
+	main := cfg.Branches.Main
+	if main == "" || len(commits) == 0 {
+		return
+	}
+
+	var port bool
+	err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Do you want to port the %d commit(s) of '%s' to '%s' as well?", len(commits), branch, main),
+		Default: false,
+	}, &port)
+	if err != nil || !port {
+		utils.Info("Not ported to '%s'. Run `dflow backport %s --to %s` if it is needed there.", main, branch, main)
+		return
+	}
+
+	name := strings.ReplaceAll(strings.TrimPrefix(branch, utils.PrefixForType(cfg, utils.TypeHotfix)), "/", "-")
+	runBackport(cfg, backportPlan{Commits: commits, Targets: []string{main}, Name: name, Push: push})
+}
+
+func init() {
+	BackportCmd.Flags().StringSlice("to", nil, "Branches to backport onto, comma-separated (e.g. release/2.3.0,support/1.x)")
+	BackportCmd.Flags().Bool("continue", false, "Continue after resolving conflicts")
+	BackportCmd.Flags().Bool("abort", false, "Abort an interrupted backport")
+	BackportCmd.Flags().Bool("push", false, "Push the targets (or the backport branches) without asking")
+
+	BackportCmd.MarkFlagsMutuallyExclusive("continue", "abort")
+
+	BackportCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	}
+	_ = BackportCmd.RegisterFlagCompletionFunc("to", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	})
+}
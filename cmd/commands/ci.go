@@ -0,0 +1,182 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// Output formats of the ci commands.
+const (
+	ciFormatText   = "text"
+	ciFormatGitHub = "github"
+	ciFormatGitLab = "gitlab"
+)
+
+// ciPullRequestEnv lists, per CI system, the environment variables holding the source and
+// target branches of the pull request being built. Gitea Actions uses the GitHub names.
+var ciPullRequestEnv = [][2]string{
+	{"GITHUB_HEAD_REF", "GITHUB_BASE_REF"},
+	{"CI_MERGE_REQUEST_SOURCE_BRANCH_NAME", "CI_MERGE_REQUEST_TARGET_BRANCH_NAME"},
+	{"BITBUCKET_BRANCH", "BITBUCKET_PR_DESTINATION_BRANCH"},
+	{"SYSTEM_PULLREQUEST_SOURCEBRANCH", "SYSTEM_PULLREQUEST_TARGETBRANCH"},
+}
+
+// CiCmd is the parent command for the checks meant to run in CI pipelines.
+//
+// Available subcommands:
+//   - check-pr: Validates the source and target branches of a pull request.
+//
+// Example usage:
+//
+//	dflow ci check-pr --source feature/login --target develop
+var CiCmd = &cobra.Command{
+	Use:   "ci",
+	Short: "Checks for CI pipelines",
+	Long: `Checks that enforce .dflow.yaml in CI pipelines. They exit non-zero when the check fails.
+
+  Examples:
+    dflow ci check-pr
+    dflow ci check-pr --source feature/login --target develop`,
+}
+
+// ciCheckPRCmd validates the direction of a pull request against the configured flow.
+var ciCheckPRCmd = &cobra.Command{
+	Use:   "check-pr",
+	Short: "Validate the source and target branches of a pull request",
+	Long: `Validate that a pull request goes in the direction of the flow:
+
+    - feature branches target flow.feature_merge (or flow.feature_base)
+    - bugfix branches target flow.bugfix_base
+    - hotfix branches target flow.hotfix_base
+    - release branches target the main branch
+    - base branches only target other base branches (e.g. develop into uat)
+
+  The branches are taken from --source and --target, or from the pull request variables of
+  GitHub Actions, Gitea Actions, GitLab CI, Bitbucket Pipelines and Azure Pipelines.
+
+  Formats:
+    text    plain messages (default)
+    github  workflow commands shown as annotations on the pull request
+            (default when GITHUB_ACTIONS=true)
+    gitlab  a Code Quality report on stdout, e.g. redirected to gl-code-quality-report.json
+
+  Examples:
+    dflow ci check-pr
+    dflow ci check-pr --source feature/login --target develop
+    dflow ci check-pr --format gitlab > gl-code-quality-report.json`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{utils.AnnotationNoBanner: "ci"},
+	Run: func(cmd *cobra.Command, args []string) {
+		source, _ := cmd.Flags().GetString("source")
+		target, _ := cmd.Flags().GetString("target")
+		format, _ := cmd.Flags().GetString("format")
+
+		if format == "" {
+			format = ciFormatText
+			if os.Getenv("GITHUB_ACTIONS") == "true" {
+				format = ciFormatGitHub
+			}
+		}
+		if format != ciFormatText && format != ciFormatGitHub && format != ciFormatGitLab {
+			utils.Error("Unknown format '%s' (expected text, github or gitlab)", format)
+			os.Exit(1)
+		}
+
+		envSource, envTarget := pullRequestFromEnv()
+		if source == "" {
+			source = envSource
+		}
+		if target == "" {
+			target = envTarget
+		}
+		if source == "" || target == "" {
+			reportCheckFailure(format, "Could not determine the pull request branches: pass --source and --target")
+			os.Exit(1)
+		}
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			reportCheckFailure(format, err.Error())
+			os.Exit(1)
+		}
+
+		if err := validators.ValidatePullRequest(cfg, source, target); err != nil {
+			reportCheckFailure(format, fmt.Sprintf("Pull request %s → %s breaks the flow: %v", source, target, err))
+			os.Exit(1)
+		}
+
+		if format == ciFormatGitLab {
+			fmt.Println("[]")
+		}
+		fmt.Fprintf(os.Stderr, "✅ Pull request %s → %s follows the flow\n", source, target)
+	},
+}
+
+// pullRequestFromEnv returns the pull request branches set by the first CI system found
+// in the environment.
+func pullRequestFromEnv() (string, string) {
+	for _, vars := range ciPullRequestEnv {
+		source, target := os.Getenv(vars[0]), os.Getenv(vars[1])
+		if source != "" && target != "" {
+			return strings.TrimPrefix(source, "refs/heads/"), strings.TrimPrefix(target, "refs/heads/")
+		}
+	}
+	return "", ""
+}
+
+// reportCheckFailure prints a failed check in the given format. The explanation always
+// goes to stderr as well, so it shows up in the job log.
+func reportCheckFailure(format string, message string) {
+	switch format {
+	case ciFormatGitHub:
+		fmt.Printf("::error title=dflow ci check-pr::%s\n", escapeWorkflowCommand(message))
+	case ciFormatGitLab:
+		report := []map[string]interface{}{{
+			"description": message,
+			"check_name":  "dflow-check-pr",
+			"fingerprint": fmt.Sprintf("%x", md5.Sum([]byte(message))),
+			"severity":    "blocker",
+			"location": map[string]interface{}{
+				"path":  ".dflow.yaml",
+				"lines": map[string]int{"begin": 1},
+			},
+		}}
+		data, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Println(string(data))
+	}
+	fmt.Fprintf(os.Stderr, "❌ %s\n", message)
+}
+
+// escapeWorkflowCommand escapes the characters GitHub treats specially in the message of a
+// workflow command.
+func escapeWorkflowCommand(message string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(message)
+}
+
+func init() {
+	CiCmd.AddCommand(ciCheckPRCmd)
+
+	ciCheckPRCmd.Flags().String("source", "", "Source branch of the pull request")
+	ciCheckPRCmd.Flags().String("target", "", "Target branch of the pull request")
+	ciCheckPRCmd.Flags().String("format", "", "Output format: text, github or gitlab")
+
+	CiCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
@@ -8,34 +8,125 @@ package commands
 
 import (
 	"fmt"
+	"path"
+	"sort"
+	"strings"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/lifecycle"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
 )
 
-// DeleteCmd deletes a Git branch locally and remotely using the dflow CLI.
+// DeleteCmd deletes Git branches locally and remotely using the dflow CLI.
 //
-// This command requires the exact name of the branch to delete. It will:
+// It accepts exact branch names or glob patterns (e.g. `feature/old-*`). It will:
 //
-//  1. Ask for confirmation before proceeding
-//  2. Delete the local branch
-//  3. Delete the corresponding remote branch from origin (if it exists)
+//  1. Resolve the patterns against local and remote branches
+//  2. Refuse configured base branches and support branches unless `--force-protected` is given
+//  3. Ask for confirmation before proceeding
+//  4. Keep branches with unmerged commits (listing them) unless `--force` is given
+//  5. Save the branch tip under refs/dflow/trash (see `dflow restore`)
+//  6. Remove the worktree the branch is checked out in, refusing one with uncommitted changes
+//  7. Delete the local branch and the corresponding branch on origin
+//
+// The pre-delete and post-delete lifecycle hooks run for every branch; a failing
+// pre-delete hook keeps that branch.
 //
 // Example usage:
 //
 //	dflow delete feature/login-form
+//	dflow delete "feature/old-*" --local-only
+//	dflow delete release/1.0.0 --remote-only
 //
 // Autocompletion suggests local branches when available.
 var DeleteCmd = &cobra.Command{
-	Use:   "delete <branch>",
+	Use:   "delete <branch|pattern>...",
 	Short: "Delete branch created previously",
-	Args:  cobra.ExactArgs(1),
-	RunE: func(cmd *cobra.Command, args []string) error {
-		branch := args[0]
+	Long: `Delete one or more branches locally and on origin.
+
+  Branch names may be glob patterns such as 'feature/old-*'.
+
+  Safety rules:
+    - Base branches from .dflow.yaml (main, develop, uat, flow bases) and support
+      branches are refused unless --force-protected is passed.
+    - Branches with commits not merged into any base branch are kept and their
+      unmerged commits are listed, unless --force is passed.
+    - A branch checked out in another worktree is deleted with its worktree, unless
+      the worktree has uncommitted changes.
+    - Every deleted branch is saved to the dflow trash first and can be
+      recovered with 'dflow restore <branch>'.
+
+  Examples:
+    dflow delete feature/login-form
+    dflow delete "feature/old-*" --local-only
+    dflow delete release/1.0.0 --remote-only`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		forceProtected, _ := cmd.Flags().GetBool("force-protected")
+		localOnly, _ := cmd.Flags().GetBool("local-only")
+		remoteOnly, _ := cmd.Flags().GetBool("remote-only")
+
+		if localOnly && remoteOnly {
+			utils.Error("--local-only and --remote-only cannot be used together")
+			return nil
+		}
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		var candidates []string
+		if !remoteOnly {
+			candidates = append(candidates, gitutils.GetLocalBranches()...)
+		}
+		if !localOnly {
+			candidates = append(candidates, gitutils.GetRemoteBranches()...)
+		}
+
+		branches, err := resolveBranchPatterns(args, candidates)
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		var targets []string
+		for _, branch := range branches {
+			// support branches are long-lived like the base branches
+			protected := utils.IsProtectedBranch(cfg, branch) || utils.BranchTypeFor(cfg, branch) == utils.TypeSupport
+			if protected && !forceProtected {
+				utils.Warn("Skipping protected branch '%s' (use --force-protected to delete it)", branch)
+				continue
+			}
+			targets = append(targets, branch)
+		}
+
+		if len(targets) == 0 {
+			utils.Info("Nothing to delete.")
+			return nil
+		}
+
+		scope := "locally and remotely"
+		if localOnly {
+			scope = "locally"
+		} else if remoteOnly {
+			scope = "from origin"
+		}
+
+		message := fmt.Sprintf("Are you sure you want to delete branch '%s' %s?", targets[0], scope)
+		if len(targets) > 1 {
+			message = fmt.Sprintf("Are you sure you want to delete %d branches %s?\n  - %s\n",
+				len(targets), scope, strings.Join(targets, "\n  - "))
+		}
+
 		var confirm bool
-		err := survey.AskOne(&survey.Confirm{
-			Message: fmt.Sprintf("Are you sure you want to delete branch '%s' locally and remotely?", branch),
+		err = survey.AskOne(&survey.Confirm{
+			Message: message,
 			Default: false,
 		}, &confirm)
 		if err != nil {
@@ -48,11 +139,156 @@ var DeleteCmd = &cobra.Command{
 			return nil
 		}
 
-		return gitutils.Delete(branch)
-	},
+		bases := utils.BaseBranches(cfg)
+		for _, branch := range gitutils.GetLocalBranches() {
+			if utils.BranchTypeFor(cfg, branch) == utils.TypeSupport {
+				bases = append(bases, branch)
+			}
+		}
+		for _, branch := range targets {
+			if !localOnly {
+				// refresh origin/<branch> so the checks and the backup see the remote tip
+				_ = gitutils.FetchRemoteBranch(branch)
+			}
+
+			hasLocal := !remoteOnly && gitutils.LocalBranchExists(branch)
+			hasTracking := gitutils.RefExists("refs/remotes/origin/" + branch)
+
+			if localOnly && !hasLocal {
+				utils.Warn("Branch '%s' does not exist locally", branch)
+				continue
+			}
+
+			if !force && (hasLocal || hasTracking) {
+				ref := "refs/heads/" + branch
+				if !hasLocal {
+					ref = "refs/remotes/origin/" + branch
+				}
+
+				unmerged, err := gitutils.UnmergedCommits(ref, bases)
+				if err != nil {
+					utils.Error(err.Error())
+					continue
+				}
+				if len(unmerged) > 0 {
+					utils.Warn("Branch '%s' has %d commit(s) not merged into %s:", branch, len(unmerged), strings.Join(bases, ", "))
+					for _, commit := range unmerged {
+						fmt.Printf("      %s\n", commit)
+					}
+					utils.Info("Keeping '%s'. Use --force to delete it anyway.", branch)
+					continue
+				}
+			}
+
+			// 🌳 a branch checked out in another worktree goes with it, changes permitting
+			worktree := ""
+			if dir := gitutils.Worktrees()[branch]; hasLocal && dir != "" {
+				if root, err := gitutils.RepoRoot(); err == nil && root != dir {
+					worktree = dir
+				}
+			}
+			if worktree != "" && len(gitutils.DirtyPaths(worktree, nil)) > 0 {
+				utils.Warn("Branch '%s' is checked out in the worktree %s, which has uncommitted changes.", branch, worktree)
+				utils.Info("Keeping '%s'. Commit or discard the changes there first.", branch)
+				continue
+			}
+
+			meta := gitutils.ResolveBranchMeta(cfg, branch)
+			hookCtx := lifecycle.Context{Type: meta.Type, Branch: branch, Base: meta.Base, Target: meta.Target}
+			if !lifecycle.RunPre(cfg, utils.HookPreDelete, hookCtx) {
+				continue
+			}
+
+			// 🗃️ keep the tip under refs/dflow/trash so `dflow restore` can bring it back
+			if hasLocal || hasTracking {
+				ref, upstream := "refs/remotes/origin/"+branch, "origin/"+branch
+				if hasLocal {
+					ref, upstream = "refs/heads/"+branch, gitutils.Upstream(branch)
+				}
+
+				entry, err := gitutils.SaveToTrash(branch, ref, upstream, cfg.Trash.Push)
+				if err != nil {
+					utils.Error(err.Error())
+					continue
+				}
+				utils.Info("Saved backup of '%s' (%s) to the dflow trash", branch, entry.ID())
+			}
+
+			if worktree != "" {
+				if err := gitutils.RemoveWorktree(worktree, false); err != nil {
+					utils.Error(err.Error())
+					continue
+				}
+				utils.Success("Removed worktree '%s'", worktree)
+			}
+
+			if hasLocal {
+				if err := gitutils.DeleteLocal(branch); err != nil {
+					utils.Error(err.Error())
+					continue
+				}
+			}
+
+			if !localOnly {
+				if err := gitutils.DeleteRemote(branch); err != nil {
+					utils.Error(err.Error())
+				}
+			}
+
+			lifecycle.RunPost(cfg, utils.HookPostDelete, hookCtx)
+		}
+
+		return nil
+	}),
+}
+
+// resolveBranchPatterns expands the given names and glob patterns against the candidate
+// branches. Plain names are returned as-is and patterns that match nothing are reported
+// with a warning. The result is sorted and free of duplicates.
+func resolveBranchPatterns(patterns []string, candidates []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var result []string
+
+	add := func(branch string) {
+		if !seen[branch] {
+			seen[branch] = true
+			result = append(result, branch)
+		}
+	}
+
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			add(pattern)
+			continue
+		}
+
+		matched := false
+		for _, branch := range candidates {
+			ok, err := path.Match(pattern, branch)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+			}
+			if ok {
+				matched = true
+				add(branch)
+			}
+		}
+
+		if !matched {
+			utils.Warn("No branches match '%s'", pattern)
+		}
+	}
+
+	sort.Strings(result)
+	return result, nil
 }
 
 func init() {
+	DeleteCmd.Flags().Bool("force", false, "Delete branches even if they contain unmerged commits")
+	DeleteCmd.Flags().Bool("force-protected", false, "Allow deleting base branches configured in .dflow.yaml and support branches")
+	DeleteCmd.Flags().Bool("local-only", false, "Only delete the local branch")
+	DeleteCmd.Flags().Bool("remote-only", false, "Only delete the branch on origin")
+
 	DeleteCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		branches := gitutils.GetLocalBranches()
 		return branches, cobra.ShellCompDirectiveNoFileComp
@@ -0,0 +1,439 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gates"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/lifecycle"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// FinishCmd merges a flow branch back into its target.
+//
+// The target is the one recorded by `dflow start` (e.g. with `--base`) or the default of
+// the branch type (`flow.feature_merge` for features, main for releases and hotfixes, the
+// bugfix base for bugfixes).
+//
+// The quality gates configured for the branch type under `gates` run first; if one fails
+// the branch is not merged, unless `--skip-gates` is given with a `--reason`. What happens
+// next depends on the merge mode of the target:
+//
+//   - auto: the target is fast-forwarded to origin and the branch is merged into it with
+//     the strategy from `workflow.merge_strategy` (no-ff, squash, rebase or ff-only) and the
+//     message template from `workflow.merge_message`. The branch can then be deleted.
+//   - manual: the branch is pushed and the pull request to open is printed, including the
+//     merge method equivalent to the configured strategy.
+//
+// Releases and hotfixes named after a version (release/1.3.0) are tagged with it (v1.3.0)
+// on the target once merged, unless the tag already exists. Before that, once the gates and
+// the pre-finish hook passed, the `version_files` of a hotfix are rewritten to its version
+// and the change is committed on the hotfix.
+//
+// Hotfixes started from a support branch (`dflow start hotfix --support 1.x`) merge into it
+// and are tagged there; their commits can then be backported to main.
+//
+// The pre-finish and post-finish lifecycle hooks run around the merge or pull request.
+//
+// Example usage:
+//
+//	dflow finish
+//	dflow finish feature/login-form --push
+//	dflow finish --strategy squash
+//	dflow finish --skip-gates --reason "tests are flaky on CI, tracked in OPS-42"
+var FinishCmd = &cobra.Command{
+	Use:   "finish [branch]",
+	Short: "Merge a feature, release, hotfix or bugfix branch into its target",
+	Long: `Finish a flow branch by merging it into its target branch.
+
+  The target is the branch it was started from with --base, or the flow default:
+  'feature_merge' for features, main for releases and hotfixes, 'bugfix_base' for bugfixes.
+
+  When the target's merge mode is 'auto', dflow merges locally using the strategy configured
+  under 'workflow.merge_strategy' (no-ff by default) and the commit message template under
+  'workflow.merge_message'. When it is 'manual', the branch is pushed and the pull request
+  to open is printed instead.
+
+  Releases and hotfixes named after a version are tagged on the target after merging
+  (release/1.3.0 → v1.3.0). Once the gates and the pre-finish hook pass, hotfixes
+  bump the files listed under 'version_files' to their version in a commit on the hotfix.
+
+  Hotfixes of a support branch are merged and tagged there, and you are offered to port
+  their commits to main through a backport branch.
+
+  Quality gates configured under 'gates.<type>' run before merging and their results are
+  added to the merge commit as trailers. Use --skip-gates with --reason to bypass them.
+
+  Examples:
+    dflow finish
+    dflow finish feature/login-form --push
+    dflow finish --strategy squash
+    dflow finish --keep
+    dflow finish --skip-gates --reason "hotfix for outage, CI runs after merge"`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		var opts finishOptions
+		opts.Strategy, _ = cmd.Flags().GetString("strategy")
+		opts.Push, _ = cmd.Flags().GetBool("push")
+		opts.Keep, _ = cmd.Flags().GetBool("keep")
+		opts.SkipGates, _ = cmd.Flags().GetBool("skip-gates")
+		opts.Reason, _ = cmd.Flags().GetString("reason")
+
+		branch := ""
+		if len(args) > 0 {
+			branch = args[0]
+		}
+		finishBranch(branch, opts)
+		return nil
+	}),
+}
+
+// finishOptions holds the flags of `dflow finish`.
+type finishOptions struct {
+	Strategy  string // merge strategy overriding workflow.merge_strategy
+	Push      bool   // push the target without asking
+	Keep      bool   // keep the branch after merging
+	SkipGates bool   // do not run the quality gates
+	Reason    string // why the gates are skipped
+}
+
+// finishBranch merges branch (the current branch when empty) into its target, or prints
+// the pull request to open when the target's merge mode is manual. Problems are reported
+// to the user, and false is returned when the branch was not merged or pushed.
+func finishBranch(branch string, opts finishOptions) bool {
+	strategy, push := opts.Strategy, opts.Push
+	if opts.SkipGates && strings.TrimSpace(opts.Reason) == "" {
+		utils.Error("--skip-gates requires a --reason explaining why the gates are skipped")
+		return false
+	}
+
+	if err := validators.EnsureOnBranch(); err != nil {
+		utils.Error(err.Error())
+		return false
+	}
+
+	if err := validators.EnsureNoOperationInProgress(); err != nil {
+		utils.Error(err.Error())
+		return false
+	}
+
+	if gitutils.IsDirty() {
+		utils.Error("You have uncommitted changes. Commit or stash them before finishing.")
+		return false
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		utils.Error(err.Error())
+		return false
+	}
+
+	if branch == "" {
+		branch = gitutils.CurrentBranch()
+	}
+
+	if !gitutils.LocalBranchExists(branch) {
+		utils.Error("Branch '%s' does not exist locally", branch)
+		return false
+	}
+
+	meta := gitutils.ResolveBranchMeta(cfg, branch)
+	if meta.Type == "" {
+		utils.Error("'%s' is not a dflow branch. Finish a feature, release, hotfix or bugfix branch.", branch)
+		return false
+	}
+
+	if meta.Type == utils.TypeSupport {
+		utils.Error("'%s' is a support branch: it is long-lived and not finished. Finish the hotfixes started from it instead.", branch)
+		return false
+	}
+
+	target := meta.Target
+	if target == "" && meta.Type == utils.TypeBackport {
+		target = utils.BackportTarget(cfg, branch)
+	}
+	if target == "" {
+		target = utils.MergeTargetForType(cfg, meta.Type)
+	}
+	if target == "" || (!gitutils.LocalBranchExists(target) && !gitutils.RemoteBranchExists(target)) {
+		utils.Error("Cannot find the branch '%s' should be merged into. Check the flow section of .dflow.yaml.", branch)
+		return false
+	}
+
+	if strategy == "" {
+		strategy = utils.GetMergeStrategy(cfg, meta.Type, target)
+	}
+	if !utils.IsValidMergeStrategy(strategy) {
+		utils.Error("Unknown merge strategy '%s'. Use: %s", strategy, strings.Join(utils.MergeStrategies, ", "))
+		return false
+	}
+
+	message := utils.RenderMergeMessage(cfg, utils.MergeMessageData{
+		Branch: branch,
+		Type:   meta.Type,
+		Ticket: meta.Ticket,
+		Author: meta.Author,
+		Target: target,
+		Base:   meta.Base,
+	})
+
+	if gateList := utils.GetGates(cfg, meta.Type); len(gateList) > 0 {
+		trailers, ok := checkGates(gateList, branch, target, opts.SkipGates, opts.Reason)
+		if !ok {
+			return false
+		}
+		message = gates.AppendTrailers(message, trailers)
+	}
+
+	hookCtx := lifecycle.Context{Type: meta.Type, Branch: branch, Base: meta.Base, Target: target}
+	if !lifecycle.RunPre(cfg, utils.HookPreFinish, hookCtx) {
+		return false
+	}
+
+	// 🔢 hotfixes ship the version they are named after in the version files, bumped once
+	// the gates and the pre-finish hook passed so that a refusal leaves no commit behind
+	if meta.Type == utils.TypeHotfix && len(cfg.VersionFiles) > 0 {
+		dir, err := branchDir(branch)
+		if err != nil {
+			utils.Error("Could not checkout '%s' to bump its version files: %v", branch, err)
+			return false
+		}
+		if !bumpVersionFiles(cfg, branch, meta.Type, dir) {
+			utils.Error("'%s' was not merged into '%s'.", branch, target)
+			return false
+		}
+	}
+
+	// releases and hotfixes named after a version are tagged with it once merged
+	tag, tagged := versioning.ReleaseTag(cfg, branch, meta.Type)
+	tagged = tagged && !gitutils.TagExists(tag)
+
+	// hotfixes of a support branch may be needed on main as well
+	var portable []string
+	if meta.Type == utils.TypeHotfix && utils.BranchTypeFor(cfg, target) == utils.TypeSupport {
+		portable = portableCommits(cfg, branch, meta)
+	}
+
+	if utils.GetMergeModeForBranch(cfg, target) == "manual" {
+		if !requestPullRequest(cfg, hookCtx, strategy, message) {
+			return false
+		}
+		if tagged {
+			utils.Info("Once it is merged, tag the release with `git tag -a %s %s` and push the tag.", tag, target)
+		}
+		lifecycle.RunPost(cfg, utils.HookPostFinish, hookCtx)
+		offerPortToMain(cfg, branch, portable, opts.Push)
+		return true
+	}
+
+	if !updateBase(target) {
+		utils.Error("Reconcile '%s' with origin (see `dflow update`) before merging into it", target)
+		return false
+	}
+
+	utils.Info("Merging '%s' into '%s' (%s)", branch, target, strategy)
+
+	if err := gitutils.MergeBranch(branch, target, strategy, message); err != nil {
+		var squash *gitutils.SquashConflictError
+		if errors.As(err, &squash) {
+			utils.Warn("The squash merge stopped because of conflicts in: %s", strings.Join(squash.Paths, ", "))
+			utils.Info("Resolve them, stage the files with `git add` and commit with `git commit -m %q`,", squash.Message)
+			utils.Info("then push '%s' and delete the branch with `dflow delete %s --force`.", target, branch)
+			utils.Info("Or undo the merge with `git reset --merge` and run `dflow finish %s` again.", branch)
+			return false
+		}
+		if op := gitutils.OperationInProgress(); op != "" {
+			utils.Warn("The %s stopped because of conflicts.", op)
+			utils.Info("Resolve them, stage the files with `git add` and complete it with `git %s --continue` (or `git commit`),", op)
+			utils.Info("or undo it with `git %s --abort`. Then run `dflow finish %s` again.", op, branch)
+			return false
+		}
+		utils.Error("Failed to merge '%s' into '%s': %v", branch, target, err)
+		return false
+	}
+
+	utils.Success("Merged '%s' into '%s'", branch, target)
+
+	if tagged {
+		if err := gitutils.CreateTag(tag, target, fmt.Sprintf("Release %s", tag)); err != nil {
+			utils.Error(err.Error())
+			tagged = false
+		} else {
+			utils.Success("Tagged '%s' as '%s'", target, tag)
+		}
+	}
+
+	if !push {
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Do you want to push '%s' to origin?", target),
+			Default: true,
+		}, &push)
+		if err != nil || !push {
+			utils.Warn("Skipping push. Run `git push origin %s` when you are ready.", target)
+		}
+	}
+	pushed := true
+	if push {
+		if err := gitutils.PushBranch(target); err != nil {
+			utils.Error(err.Error())
+			pushed = false
+		}
+		if tagged {
+			if err := gitutils.PushTag(tag); err != nil {
+				utils.Error(err.Error())
+				pushed = false
+			}
+		}
+	}
+
+	lifecycle.RunPost(cfg, utils.HookPostFinish, hookCtx)
+
+	if !opts.Keep {
+		removeFinishedBranch(cfg, branch, meta)
+	}
+
+	offerPortToMain(cfg, branch, portable, opts.Push)
+	return pushed
+}
+
+// checkGates runs the quality gates of a branch before it is merged and returns the
+// commit trailers recording their outcome. It returns false when a gate failed.
+//
+// With skip, the gates are not run; the reason is logged and recorded as a trailer instead.
+func checkGates(gateList []utils.Gate, branch string, target string, skip bool, reason string) ([]string, bool) {
+	if skip {
+		if err := gates.LogSkip(branch, target, gitutils.CurrentAuthor(), reason); err != nil {
+			utils.Warn("Could not log skipped gates: %v", err)
+		}
+		utils.Warn("Skipping %d quality gate(s): %s", len(gateList), reason)
+		return []string{fmt.Sprintf("%s: %s", gates.TrailerSkipped, reason)}, true
+	}
+
+	// gates check the code of the branch being finished
+	root, err := branchDir(branch)
+	if err != nil {
+		utils.Error("Could not checkout '%s' to run its gates: %v", branch, err)
+		return nil, false
+	}
+
+	results := gates.Run(gateList, root)
+	fmt.Println()
+	if !gates.Passed(results) {
+		utils.Error("Quality gates failed; '%s' was not merged into '%s'.", branch, target)
+		utils.Info("Fix the failures, or use `--skip-gates --reason \"...\"` to merge anyway.")
+		return nil, false
+	}
+	utils.Success("All %d quality gate(s) passed", len(results))
+
+	trailers := make([]string, 0, len(results))
+	for _, result := range results {
+		trailers = append(trailers, result.Trailer())
+	}
+	return trailers, true
+}
+
+// branchDir returns the working tree where branch is checked out: its worktree when it
+// has one, otherwise the current one after checking branch out.
+func branchDir(branch string) (string, error) {
+	if dir := gitutils.Worktrees()[branch]; dir != "" {
+		return dir, nil
+	}
+	if err := gitutils.Checkout(branch); err != nil {
+		return "", err
+	}
+	return gitutils.RepoRoot()
+}
+
+// requestPullRequest publishes the branch and prints the pull request to open for
+// targets whose merge mode is manual. It returns false if the branch could not be pushed.
+func requestPullRequest(cfg *utils.Config, hookCtx lifecycle.Context, strategy string, message string) bool {
+	branch, target := hookCtx.Branch, hookCtx.Target
+	if err := publishBranch(cfg, hookCtx); err != nil {
+		utils.Error(err.Error())
+		return false
+	}
+
+	utils.Info("'%s' uses manual merges. Open a pull request to finish '%s':", target, branch)
+	fmt.Printf("      from:   %s\n", branch)
+	fmt.Printf("      into:   %s\n", target)
+	fmt.Printf("      method: %s (dflow strategy: %s)\n", utils.PullRequestMergeMethod(strategy), strategy)
+	fmt.Printf("      title:  %s\n", strings.SplitN(message, "\n", 2)[0])
+	return true
+}
+
+// removeFinishedBranch offers to delete a merged branch locally and on origin, removing
+// its worktree first when it was started with --worktree. Like with `dflow delete`, the
+// tip is kept in the dflow trash and the pre-delete and post-delete hooks run around it.
+func removeFinishedBranch(cfg *utils.Config, branch string, meta gitutils.BranchMeta) {
+	var remove bool
+	err := survey.AskOne(&survey.Confirm{
+		Message: fmt.Sprintf("Delete the finished branch '%s'?", branch),
+		Default: true,
+	}, &remove)
+	if err != nil || !remove {
+		utils.Info("Keeping '%s'. Delete it later with `dflow delete %s`.", branch, branch)
+		return
+	}
+
+	hookCtx := lifecycle.Context{Type: meta.Type, Branch: branch, Base: meta.Base, Target: meta.Target}
+	if !lifecycle.RunPre(cfg, utils.HookPreDelete, hookCtx) {
+		return
+	}
+
+	if meta.Worktree != "" {
+		if err := gitutils.RemoveWorktree(meta.Worktree, false); err != nil {
+			utils.Error(err.Error())
+			return
+		}
+		utils.Success("Removed worktree '%s'", meta.Worktree)
+	}
+
+	upstream := gitutils.Upstream(branch)
+	entry, err := gitutils.SaveToTrash(branch, "refs/heads/"+branch, upstream, cfg.Trash.Push)
+	if err != nil {
+		utils.Error(err.Error())
+		return
+	}
+	utils.Info("Saved backup of '%s' (%s) to the dflow trash", branch, entry.ID())
+
+	if err := gitutils.DeleteLocal(branch); err != nil {
+		utils.Error(err.Error())
+		return
+	}
+
+	if upstream != "" {
+		if err := gitutils.DeleteRemote(branch); err != nil {
+			utils.Error(err.Error())
+		}
+	}
+
+	lifecycle.RunPost(cfg, utils.HookPostDelete, hookCtx)
+}
+
+func init() {
+	FinishCmd.Flags().String("strategy", "", "Merge strategy: no-ff, squash, rebase or ff-only (defaults to workflow.merge_strategy)")
+	FinishCmd.Flags().Bool("push", false, "Push the target branch after merging without asking")
+	FinishCmd.Flags().Bool("keep", false, "Keep the branch after merging")
+	FinishCmd.Flags().Bool("skip-gates", false, "Merge without running the quality gates (requires --reason)")
+	FinishCmd.Flags().String("reason", "", "Why the quality gates are skipped; logged and added to the merge commit")
+
+	FinishCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	}
+
+	_ = FinishCmd.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return utils.MergeStrategies, cobra.ShellCompDirectiveNoFileComp
+	})
+}
@@ -0,0 +1,274 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// managedHooks lists the git hooks written by `dflow hooks install`.
+var managedHooks = []string{"pre-push", "commit-msg", "post-checkout"}
+
+// HooksCmd is the parent command for the git hooks that enforce the dflow model locally.
+//
+// Available subcommands:
+//   - install: Writes the managed pre-push, commit-msg and post-checkout hooks.
+//   - uninstall: Removes them, restoring any hooks they chained.
+//   - server: Generates a standalone pre-receive hook for Git servers.
+//   - run: Runs the checks of a hook; called by the installed hooks themselves.
+//
+// Example usage:
+//
+//	dflow hooks install
+//	dflow hooks uninstall
+//	dflow hooks server --output pre-receive
+var HooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Install git hooks that enforce the dflow model",
+	Long: `Manage the git hooks that enforce .dflow.yaml on this clone.
+
+  Managed hooks:
+    - pre-push: blocks direct pushes to branches whose merge mode is manual (e.g. main),
+      and pushes of branches that do not start with a configured prefix.
+    - commit-msg: enforces the commit convention from 'commits.pattern'
+      (Conventional Commits by default).
+    - post-checkout: warns when switching to a branch outside the dflow model.
+
+  Existing hooks are kept and run before dflow's checks. Bypass the hooks for a single
+  command with git's --no-verify.
+
+  For servers, 'dflow hooks server' generates an equivalent pre-receive hook.
+
+  Examples:
+    dflow hooks install
+    dflow hooks uninstall
+    dflow hooks server --output pre-receive`,
+}
+
+// hooksInstallCmd writes the managed git hooks.
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Install the managed pre-push, commit-msg and post-checkout hooks",
+	Args:  cobra.NoArgs,
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		dir, err := gitutils.HooksDir()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		for _, hook := range managedHooks {
+			chained, err := gitutils.InstallHook(dir, hook)
+			if err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+			if chained {
+				utils.Success("Installed %s hook (your existing hook runs first, kept as %s%s)", hook, hook, gitutils.ChainedHookSuffix)
+			} else {
+				utils.Success("Installed %s hook", hook)
+			}
+		}
+
+		utils.Info("Hooks installed in %s", dir)
+		return nil
+	}),
+}
+
+// hooksUninstallCmd removes the managed git hooks.
+var hooksUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the managed hooks and restore chained ones",
+	Args:  cobra.NoArgs,
+	RunE: validators.WithChecks(true, func(cmd *cobra.Command, args []string) error {
+		dir, err := gitutils.HooksDir()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		removedAny := false
+		for _, hook := range managedHooks {
+			removed, restored, err := gitutils.UninstallHook(dir, hook)
+			if err != nil {
+				utils.Error(err.Error())
+				continue
+			}
+			if !removed {
+				continue
+			}
+			removedAny = true
+			if restored {
+				utils.Success("Removed %s hook and restored your previous one", hook)
+			} else {
+				utils.Success("Removed %s hook", hook)
+			}
+		}
+
+		if !removedAny {
+			utils.Info("No dflow-managed hooks are installed.")
+		}
+		return nil
+	}),
+}
+
+// hooksServerCmd generates the standalone pre-receive hook for Git servers.
+var hooksServerCmd = &cobra.Command{
+	Use:   "server",
+	Short: "Generate a server-side pre-receive hook enforcing .dflow.yaml",
+	Long: `Generate a standalone pre-receive hook for bare repositories and self-hosted servers
+  (e.g. Gitea), where client hooks cannot be relied on. It only needs sh, awk, grep and git.
+
+  On every push, the hook reads .dflow.yaml from the repository and rejects:
+    - branches that are not base branches and do not start with a configured prefix
+    - direct updates to branches whose merge mode is manual
+      (allowed when DFLOW_ALLOW_MANUAL=1, or for pull request merges made by Gitea)
+    - deletions and non-fast-forward updates of base branches
+    - tags that do not match 'versioning.tag_pattern' (v<semver> by default)
+
+  Examples:
+    dflow hooks server --output pre-receive
+    dflow hooks server > /srv/git/project.git/hooks/pre-receive`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{utils.AnnotationNoBanner: "server"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		if output == "" || output == "-" {
+			fmt.Print(gitutils.PreReceiveHook())
+			return nil
+		}
+
+		if err := os.WriteFile(output, []byte(gitutils.PreReceiveHook()), 0755); err != nil {
+			utils.Error("Failed to write '%s': %v", output, err)
+			return nil
+		}
+		utils.Success("Wrote pre-receive hook to %s", output)
+		utils.Info("Copy it to <repo>.git/hooks/pre-receive on the server and make sure it is executable.")
+		return nil
+	},
+}
+
+// hooksRunCmd runs the checks behind a managed hook. It is invoked by the hook scripts
+// with the arguments and stdin Git gave them, and exits non-zero to reject the operation.
+var hooksRunCmd = &cobra.Command{
+	Use:         "run <hook> [args...]",
+	Short:       "Run the checks of a managed hook (used by the installed hooks)",
+	Hidden:      true,
+	Args:        cobra.MinimumNArgs(1),
+	Annotations: map[string]string{utils.AnnotationNoBanner: "hook"},
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			// repositories without (valid) dflow config are not enforced
+			if _, statErr := os.Stat(utils.ConfigPath()); statErr == nil {
+				utils.Warn("dflow hooks skipped: %v", err)
+			}
+			return
+		}
+
+		var failures []string
+		switch args[0] {
+		case "pre-push":
+			failures = checkPrePush(cfg)
+		case "commit-msg":
+			failures = checkCommitMsg(cfg, args[1:])
+		case "post-checkout":
+			warnPostCheckout(cfg, args[1:])
+		default:
+			utils.Error("Unknown hook '%s'", args[0])
+			os.Exit(1)
+		}
+
+		if len(failures) > 0 {
+			for _, failure := range failures {
+				utils.Error(failure)
+			}
+			utils.Info("Rejected by the dflow %s hook (bypass with --no-verify).", args[0])
+			os.Exit(1)
+		}
+	},
+}
+
+// checkPrePush validates every branch update listed on stdin by Git, in the form
+// `<local ref> <local sha> <remote ref> <remote sha>`. Deletions of remote branches,
+// whose local sha is all zeros, are not checked.
+func checkPrePush(cfg *utils.Config) []string {
+	var failures []string
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || !strings.HasPrefix(fields[2], "refs/heads/") {
+			continue
+		}
+		if strings.Trim(fields[1], "0") == "" {
+			continue
+		}
+
+		branch := strings.TrimPrefix(fields[2], "refs/heads/")
+		if err := validators.ValidatePush(cfg, branch); err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	return failures
+}
+
+// checkCommitMsg validates the message file Git passes to the commit-msg hook.
+func checkCommitMsg(cfg *utils.Config, args []string) []string {
+	if len(args) == 0 {
+		return []string{"commit-msg hook called without a message file"}
+	}
+
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return []string{fmt.Sprintf("could not read commit message: %v", err)}
+	}
+
+	if err := validators.ValidateCommitMessage(utils.GetCommitPattern(cfg), string(data)); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
+// warnPostCheckout reminds the user of the flow after switching branches. Git ignores the
+// exit status of post-checkout, so it only prints warnings.
+func warnPostCheckout(cfg *utils.Config, args []string) {
+	// the third argument is 1 for branch checkouts and 0 for file checkouts
+	if len(args) < 3 || args[2] != "1" || gitutils.IsDetachedHead() {
+		return
+	}
+
+	branch := gitutils.CurrentBranch()
+	if err := validators.ValidatePush(cfg, branch); err != nil {
+		utils.Warn("%v. Use `dflow start` to create flow branches.", err)
+	}
+}
+
+func init() {
+	HooksCmd.AddCommand(hooksInstallCmd)
+	HooksCmd.AddCommand(hooksUninstallCmd)
+	HooksCmd.AddCommand(hooksServerCmd)
+	HooksCmd.AddCommand(hooksRunCmd)
+
+	hooksServerCmd.Flags().StringP("output", "o", "", "Write the hook to this file instead of stdout")
+
+	HooksCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
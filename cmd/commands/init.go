@@ -125,6 +125,8 @@ var InitCmd = &cobra.Command{
 		cfg.Branches.Releases = "release/"
 		cfg.Branches.Hotfixes = "hotfix/"
 		cfg.Branches.Bugfixes = "bugfix/"
+		cfg.Branches.Supports = "support/"
+		cfg.Branches.Backports = "backport/"
 
 		cfg.Flow.FeatureBase = uatBranch
 		cfg.Flow.FeatureMerge = developBranch
@@ -0,0 +1,99 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// ListCmd lists the local flow branches (features, releases, hotfixes and bugfixes).
+//
+// For each branch it shows the type, the base it was started from, the ticket and the
+// worktree it is checked out in, if any. Types and bases come from the metadata recorded
+// by `dflow start`, falling back to the configured prefixes.
+//
+// Example usage:
+//
+//	dflow list
+//	dflow list --type feature
+var ListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List flow branches and where they are checked out",
+	Args:  cobra.NoArgs,
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		filter, _ := cmd.Flags().GetString("type")
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if filter != "" {
+			resolved, ok := utils.ResolveBranchType(filter)
+			if !ok {
+				utils.Error("Unknown type '%s'. Use: feature, release, hotfix, bugfix", filter)
+				return nil
+			}
+			filter = resolved
+		}
+
+		current := gitutils.CurrentBranch()
+		worktrees := gitutils.Worktrees()
+		root, _ := gitutils.RepoRoot()
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "  BRANCH\tTYPE\tBASE\tTICKET\tWORKTREE")
+
+		found := 0
+		for _, branch := range gitutils.GetLocalBranches() {
+			meta := gitutils.ResolveBranchMeta(cfg, branch)
+			if meta.Type == "" || (filter != "" && meta.Type != filter) {
+				continue
+			}
+
+			marker := " "
+			if branch == current {
+				marker = "*"
+			}
+
+			worktree := worktrees[branch]
+			if worktree == root {
+				worktree = "(this checkout)"
+			}
+
+			fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%s\n", marker, branch, meta.Type, meta.Base, dash(meta.Ticket), dash(worktree))
+			found++
+		}
+
+		if found == 0 {
+			utils.Info("No flow branches found.")
+			return nil
+		}
+
+		return w.Flush()
+	}),
+}
+
+// dash returns value, or "-" when it is empty, for tabular output.
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+func init() {
+	ListCmd.Flags().String("type", "", "Only list branches of this type (feature, release, hotfix, bugfix)")
+}
@@ -0,0 +1,305 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// PromoteCmd promotes an environment branch into the next one of the chain, e.g. develop
+// into uat and uat into main.
+//
+// The allowed promotions follow `promotion.chain` (develop → uat → main by default): each
+// branch is promoted into the one after it. Both branches are fast-forwarded to origin,
+// the flow branches and commits being promoted are listed, and then:
+//
+//   - auto: the branch is merged into the next one with `promotion.strategy` (no-ff or
+//     ff-only) and the message template from `promotion.message`.
+//   - manual: the pull request to open is printed.
+//
+// With `--tag` (or `promotion.tag: true`), the merged target is tagged after the format
+// of `promotion.tag_format`, numbered per day, e.g. uat-2026-10-16.1.
+//
+// Example usage:
+//
+//	dflow promote develop uat
+//	dflow promote uat main --tag --push
+//	dflow promote develop uat --dry-run
+var PromoteCmd = &cobra.Command{
+	Use:   "promote <from> <to>",
+	Short: "Promote an environment branch into the next one (e.g. develop into uat)",
+	Long: `Promote an environment branch into the next one of the promotion chain.
+
+  The chain is 'promotion.chain' in .dflow.yaml, or develop → uat → main as configured
+  under 'branches'; each branch can only be promoted into the one that follows it.
+
+  Both branches are updated from origin and the flow branches and commits being promoted
+  are listed. When the merge mode of <to> is 'auto', <from> is merged into it with the
+  strategy of 'promotion.strategy' (no-ff by default, or ff-only). When it is 'manual',
+  the pull request to open is printed instead.
+
+  With --tag, or 'promotion.tag: true', the promotion is tagged on <to> after the format
+  of 'promotion.tag_format' ('{to}-{date}.{n}' by default, e.g. uat-2026-10-16.1).
+
+  Examples:
+    dflow promote develop uat
+    dflow promote uat main --tag --push
+    dflow promote develop uat --dry-run`,
+	Args: cobra.ExactArgs(2),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		var opts promoteOptions
+		opts.Strategy, _ = cmd.Flags().GetString("strategy")
+		opts.Push, _ = cmd.Flags().GetBool("push")
+		opts.DryRun, _ = cmd.Flags().GetBool("dry-run")
+		opts.Tag = cfg.Promotion.Tag
+		if cmd.Flags().Changed("tag") {
+			opts.Tag, _ = cmd.Flags().GetBool("tag")
+		}
+
+		promote(cfg, args[0], args[1], opts)
+		return nil
+	}),
+}
+
+// promoteOptions holds the flags of `dflow promote`.
+type promoteOptions struct {
+	Strategy string // merge strategy overriding promotion.strategy
+	Tag      bool   // tag the promotion
+	Push     bool   // push the target without asking
+	DryRun   bool   // only list what would be promoted
+}
+
+// promote merges from into to, or prints the pull request to open when the merge mode of
+// to is manual. Problems are reported to the user.
+func promote(cfg *utils.Config, from string, to string, opts promoteOptions) {
+	if !utils.IsPromotion(cfg, from, to) {
+		utils.Error("'%s' cannot be promoted into '%s'. Promotions follow the chain %s.",
+			from, to, strings.Join(utils.GetPromotionChain(cfg), " → "))
+		return
+	}
+
+	strategy := opts.Strategy
+	if strategy == "" {
+		strategy = utils.GetPromotionStrategy(cfg)
+	}
+	if !utils.IsValidPromotionStrategy(strategy) {
+		utils.Error("Unknown promotion strategy '%s'. Use: %s", strategy, strings.Join(utils.PromotionStrategies, ", "))
+		return
+	}
+
+	if err := validators.EnsureOnBranch(); err != nil {
+		utils.Error(err.Error())
+		return
+	}
+	if err := validators.EnsureNoOperationInProgress(); err != nil {
+		utils.Error(err.Error())
+		return
+	}
+	if gitutils.IsDirty() {
+		utils.Error("You have uncommitted changes. Commit or stash them before promoting.")
+		return
+	}
+
+	for _, branch := range []string{from, to} {
+		if !updateBase(branch) {
+			utils.Error("Reconcile '%s' with origin (see `dflow update`) before promoting", branch)
+			return
+		}
+		if !gitutils.LocalBranchExists(branch) {
+			utils.Error("Branch '%s' does not exist locally or on origin", branch)
+			return
+		}
+	}
+
+	count := gitutils.CommitCount(to, from)
+	if count == 0 {
+		utils.Success("'%s' already contains everything in '%s': nothing to promote", to, from)
+		return
+	}
+
+	utils.Info("Promoting %d commit(s) from '%s' to '%s':", count, from, to)
+	for _, commit := range promotedCommits(cfg, to, from) {
+		if commit.Merge {
+			label := commit.Subject
+			if branch := mergedBranch(cfg, commit.Subject); branch != "" {
+				label = branch
+			}
+			fmt.Printf("      🔀 %.7s %s\n", commit.Hash, label)
+		} else {
+			fmt.Printf("      •  %.7s %s\n", commit.Hash, commit.Subject)
+		}
+	}
+
+	if opts.DryRun {
+		utils.Info("Dry run: '%s' was not promoted.", from)
+		return
+	}
+
+	tag := ""
+	if opts.Tag {
+		tag = nextPromotionTag(cfg, from, to, time.Now())
+	}
+	message := utils.RenderPromotionMessage(cfg, from, to)
+
+	if utils.GetMergeModeForBranch(cfg, to) == "manual" {
+		utils.Info("'%s' uses manual merges. Open a pull request to promote '%s':", to, from)
+		fmt.Printf("      from:   %s\n", from)
+		fmt.Printf("      into:   %s\n", to)
+		fmt.Printf("      method: %s (dflow strategy: %s)\n", utils.PullRequestMergeMethod(strategy), strategy)
+		fmt.Printf("      title:  %s\n", message)
+		if tag != "" {
+			utils.Info("Once it is merged, tag the promotion with `git tag -a %s %s` and push the tag.", tag, to)
+		}
+		return
+	}
+
+	current := gitutils.CurrentBranch()
+	utils.Info("Merging '%s' into '%s' (%s)", from, to, strategy)
+
+	if err := gitutils.MergeBranch(from, to, strategy, message); err != nil {
+		if op := gitutils.OperationInProgress(); op != "" {
+			utils.Warn("The %s stopped because of conflicts.", op)
+			utils.Info("Resolve them, stage the files with `git add` and complete it with `git %s --continue` (or `git commit`),", op)
+			utils.Info("or undo it with `git %s --abort`. Then push '%s'.", op, to)
+			return
+		}
+		utils.Error("Failed to promote '%s' into '%s': %v", from, to, err)
+		if strategy == utils.MergeFFOnly {
+			utils.Info("'%s' has commits that are not in '%s'. Use `--strategy %s` to merge them.", to, from, utils.MergeNoFF)
+		}
+		return
+	}
+
+	utils.Success("Promoted '%s' into '%s'", from, to)
+
+	if tag != "" {
+		if err := gitutils.CreateTag(tag, to, fmt.Sprintf("Promotion of %s to %s", from, to)); err != nil {
+			utils.Error(err.Error())
+			tag = ""
+		} else {
+			utils.Success("Tagged '%s' as '%s'", to, tag)
+		}
+	}
+
+	push := opts.Push
+	if !push {
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Do you want to push '%s' to origin?", to),
+			Default: true,
+		}, &push)
+		if err != nil || !push {
+			utils.Warn("Skipping push. Run `git push origin %s` when you are ready.", to)
+		}
+	}
+	if push {
+		if err := gitutils.PushBranch(to); err != nil {
+			utils.Error(err.Error())
+		}
+		if tag != "" {
+			if err := gitutils.PushTag(tag); err != nil {
+				utils.Error(err.Error())
+			}
+		}
+	}
+
+	if current != "" && current != to {
+		if err := gitutils.Checkout(current); err != nil {
+			utils.Warn("Could not return to '%s'", current)
+		}
+	}
+}
+
+// promotedCommits returns the commits of the range `base..tip` following first parents,
+// oldest first. Merges of other environment branches, such as an earlier promotion of
+// develop into uat, are replaced by the commits they brought, so that the flow branches
+// promoted along with them are listed.
+func promotedCommits(cfg *utils.Config, base string, tip string) []gitutils.Commit {
+	var commits []gitutils.Commit
+	for _, commit := range gitutils.MainlineCommits(base, tip) {
+		parents := gitutils.CommitParents(commit.Hash)
+		if commit.Merge && len(parents) == 2 && mergedBranch(cfg, commit.Subject) == "" {
+			if nested := promotedCommits(cfg, parents[0], parents[1]); len(nested) > 0 {
+				commits = append(commits, nested...)
+				continue
+			}
+		}
+		commits = append(commits, commit)
+	}
+	return commits
+}
+
+// nextPromotionTag returns the first promotion tag of the day that does not exist yet.
+func nextPromotionTag(cfg *utils.Config, from string, to string, now time.Time) string {
+	n := 1
+	for gitutils.TagExists(utils.RenderPromotionTag(cfg, from, to, now, n)) {
+		n++
+	}
+	return utils.RenderPromotionTag(cfg, from, to, now, n)
+}
+
+// mergedBranch returns the flow branch named in the subject of a merge commit, such as
+// "Merge feature 'feature/login' into develop" or "Merge pull request #12 from org/feature/login",
+// or an empty string when there is none.
+func mergedBranch(cfg *utils.Config, subject string) string {
+	for _, word := range strings.Fields(subject) {
+		word = strings.Trim(word, `'"`)
+		for {
+			if utils.BranchTypeFor(cfg, word) != "" {
+				return word
+			}
+			i := strings.Index(word, "/")
+			if i < 0 {
+				break
+			}
+			word = word[i+1:]
+		}
+	}
+	return ""
+}
+
+func init() {
+	PromoteCmd.Flags().String("strategy", "", "Merge strategy: no-ff or ff-only (defaults to promotion.strategy)")
+	PromoteCmd.Flags().Bool("tag", false, "Tag the promotion after promotion.tag_format (defaults to promotion.tag)")
+	PromoteCmd.Flags().Bool("push", false, "Push the target branch after merging without asking")
+	PromoteCmd.Flags().Bool("dry-run", false, "Only list what would be promoted")
+
+	PromoteCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, err := utils.LoadConfig()
+		if err != nil || len(args) > 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		if len(args) == 1 {
+			if next := utils.NextEnvironment(cfg, args[0]); next != "" {
+				return []string{next}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		chain := utils.GetPromotionChain(cfg)
+		if len(chain) == 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return chain[:len(chain)-1], cobra.ShellCompDirectiveNoFileComp
+	}
+
+	_ = PromoteCmd.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return utils.PromotionStrategies, cobra.ShellCompDirectiveNoFileComp
+	})
+}
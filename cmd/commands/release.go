@@ -0,0 +1,271 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// ReleaseCmd is the parent command for release candidates and their promotion.
+//
+// Available subcommands:
+//   - rc: Tags the next release candidate (vX.Y.Z-rc.N) on a release branch.
+//   - promote: Tags the final version and finishes the release branch.
+//   - list: Shows each release line with its candidates.
+//
+// Example usage:
+//
+//	dflow release rc --push
+//	dflow release promote
+//	dflow release list
+var ReleaseCmd = &cobra.Command{
+	Use:   "release",
+	Short: "Tag release candidates and promote releases",
+	Long: `Manage release candidates of release branches, e.g. for deployments to UAT.
+
+  The version comes from the release branch name (release/1.3.0, or release/2026.10.1 with
+  'versioning.scheme: calver'), or with semver is computed as with 'dflow version compute'.
+  The label and numbering of candidates are set under 'versioning.candidates' (rc.1, rc.2,
+  ... by default).
+
+  Examples:
+    dflow release rc --push
+    dflow release promote
+    dflow release list`,
+}
+
+// releaseRcCmd tags the next release candidate.
+var releaseRcCmd = &cobra.Command{
+	Use:   "rc [branch]",
+	Short: "Tag the next release candidate on a release branch",
+	Long: `Create the next release candidate tag, e.g. v1.3.0-rc.2, on the tip of the current
+  release branch (or the given one).
+
+  Examples:
+    dflow release rc
+    dflow release rc release/1.3.0 --push`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		push, _ := cmd.Flags().GetBool("push")
+
+		cfg, branch, version, ok := resolveRelease(args)
+		if !ok {
+			return nil
+		}
+
+		final := utils.GetTagPrefix(cfg) + version
+		if gitutils.TagExists(final) {
+			utils.Error("%s is already released as '%s'", version, final)
+			return nil
+		}
+
+		tip := gitutils.HeadCommit("refs/heads/" + branch)
+		if candidates := versioning.Candidates(cfg, version); len(candidates) > 0 {
+			last := candidates[len(candidates)-1]
+			if last.Commit == tip {
+				utils.Error("The tip of '%s' is already tagged as '%s'. Commit the fixes before tagging a new candidate.", branch, last.Name)
+				return nil
+			}
+		}
+
+		tag, _ := versioning.NextCandidate(cfg, version)
+		if err := gitutils.CreateTag(tag, tip, fmt.Sprintf("Release candidate %s", tag)); err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+		utils.Success("Tagged '%s' as release candidate '%s'", branch, tag)
+
+		pushTag(tag, push)
+		return nil
+	}),
+}
+
+// releasePromoteCmd tags the final version of a release and finishes its branch.
+var releasePromoteCmd = &cobra.Command{
+	Use:   "promote [branch]",
+	Short: "Tag the final version of a release and finish it",
+	Long: `Promote the latest release candidate: tag its commit with the final version (e.g. v1.3.0)
+  and finish the release branch like 'dflow finish' (merge into main, or print the pull
+  request when main uses manual merges).
+
+  The tip of the branch must be the latest candidate, so exactly what was tested in UAT is
+  released. Use --force to promote commits without a candidate.
+
+  Examples:
+    dflow release promote
+    dflow release promote release/1.3.0 --push`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		push, _ := cmd.Flags().GetBool("push")
+		keep, _ := cmd.Flags().GetBool("keep")
+		force, _ := cmd.Flags().GetBool("force")
+
+		cfg, branch, version, ok := resolveRelease(args)
+		if !ok {
+			return nil
+		}
+
+		tip := gitutils.HeadCommit("refs/heads/" + branch)
+		final := utils.GetTagPrefix(cfg) + version
+
+		if gitutils.TagExists(final) {
+			// a previous promote tagged the release but could not finish it
+			if gitutils.HeadCommit(final) != tip {
+				utils.Error("'%s' already exists on another commit than the tip of '%s'", final, branch)
+				return nil
+			}
+			utils.Info("'%s' is already tagged as '%s'", branch, final)
+		} else {
+			candidates := versioning.Candidates(cfg, version)
+			switch {
+			case force:
+			case len(candidates) == 0:
+				utils.Error("%s has no release candidate. Tag one with `dflow release rc`, or use --force.", version)
+				return nil
+			case candidates[len(candidates)-1].Commit != tip:
+				last := candidates[len(candidates)-1]
+				utils.Error("'%s' has %d commit(s) after '%s' that were not released as a candidate.",
+					branch, gitutils.CommitCount(last.Commit, tip), last.Name)
+				utils.Info("Tag a new candidate with `dflow release rc`, or use --force.")
+				return nil
+			}
+
+			if err := gitutils.CreateTag(final, tip, fmt.Sprintf("Release %s", final)); err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+			utils.Success("Tagged '%s' as '%s'", branch, final)
+		}
+
+		pushTag(final, push)
+		finishBranch(branch, finishOptions{Push: push, Keep: keep})
+		return nil
+	}),
+}
+
+// releaseListCmd shows the release lines with their candidates.
+var releaseListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List releases with their candidates",
+	Args:  cobra.NoArgs,
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		releases := versioning.Releases(cfg)
+		if len(releases) == 0 {
+			utils.Info("No releases found.")
+			return nil
+		}
+
+		for _, release := range releases {
+			status := "in progress"
+			switch {
+			case release.Final != nil:
+				status = fmt.Sprintf("released %s as %s", release.Final.Date, release.Final.Name)
+			case len(release.Candidates) == 0 && release.Branch == "":
+				continue
+			}
+
+			fmt.Printf("📦 %-10s %s", release.Version, status)
+			if release.Branch != "" {
+				fmt.Printf("  (%s)", release.Branch)
+			}
+			fmt.Println()
+
+			for _, candidate := range release.Candidates {
+				fmt.Printf("     %-12s %s  %.7s\n", candidate.PreRelease, candidate.Date, candidate.Commit)
+			}
+		}
+		return nil
+	}),
+}
+
+// resolveRelease loads the configuration and returns the release branch named in args
+// (the current branch by default) with the version it releases. Problems are reported
+// to the user and return false.
+func resolveRelease(args []string) (*utils.Config, string, string, bool) {
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		utils.Error(err.Error())
+		return nil, "", "", false
+	}
+
+	branch := gitutils.CurrentBranch()
+	if len(args) > 0 {
+		branch = args[0]
+	}
+	if branch == "" || !gitutils.LocalBranchExists(branch) {
+		utils.Error("Branch '%s' does not exist locally", branch)
+		return nil, "", "", false
+	}
+
+	if gitutils.ResolveBranchMeta(cfg, branch).Type != utils.TypeRelease {
+		utils.Error("'%s' is not a release branch", branch)
+		return nil, "", "", false
+	}
+
+	version, err := versioning.ReleaseVersion(cfg, branch)
+	if err != nil {
+		utils.Error(err.Error())
+		return nil, "", "", false
+	}
+	return cfg, branch, version, true
+}
+
+// pushTag pushes a tag to origin, asking first unless push is set.
+func pushTag(tag string, push bool) {
+	if !push {
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("Do you want to push '%s' to origin?", tag),
+			Default: true,
+		}, &push)
+		if err != nil || !push {
+			utils.Warn("Skipping push. Run `git push origin %s` when you are ready.", tag)
+			return
+		}
+	}
+
+	if err := gitutils.PushTag(tag); err != nil {
+		utils.Error(err.Error())
+	}
+}
+
+func init() {
+	ReleaseCmd.AddCommand(releaseRcCmd)
+	ReleaseCmd.AddCommand(releasePromoteCmd)
+	ReleaseCmd.AddCommand(releaseListCmd)
+
+	releaseRcCmd.Flags().Bool("push", false, "Push the tag without asking")
+	releasePromoteCmd.Flags().Bool("push", false, "Push the tag and main without asking")
+	releasePromoteCmd.Flags().Bool("keep", false, "Keep the release branch after merging")
+	releasePromoteCmd.Flags().Bool("force", false, "Promote even if the tip is not the latest release candidate")
+
+	releaseBranches := func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	}
+	releaseRcCmd.ValidArgsFunction = releaseBranches
+	releasePromoteCmd.ValidArgsFunction = releaseBranches
+
+	ReleaseCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
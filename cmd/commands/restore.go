@@ -0,0 +1,112 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// RestoreCmd recreates a branch previously removed with `dflow delete`.
+//
+// It uses the most recent backup stored under refs/dflow/trash/<branch>/ unless a
+// specific backup is selected with `--at`. If the branch had an upstream when it was
+// deleted, the upstream is restored too (pushing the branch again if needed).
+//
+// Example usage:
+//
+//	dflow restore feature/login-form
+//	dflow restore feature/login-form --at 20251016-142501
+//	dflow restore feature/login-form --as feature/login-form-v2
+var RestoreCmd = &cobra.Command{
+	Use:   "restore <branch>",
+	Short: "Restore a branch removed with dflow delete",
+	Long: `Restore a branch from the dflow trash.
+
+  Every branch deleted with 'dflow delete' is saved under refs/dflow/trash/<branch>/<timestamp>.
+  This command recreates the branch from the latest backup and restores its upstream.
+
+  Examples:
+    dflow restore feature/login-form
+    dflow restore feature/login-form --at 20251016-142501
+    dflow restore feature/login-form --as feature/login-form-v2
+
+  Use 'dflow trash list' to see the available backups.`,
+	Args: cobra.ExactArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		branch := args[0]
+		at, _ := cmd.Flags().GetString("at")
+		as, _ := cmd.Flags().GetString("as")
+
+		entries, err := gitutils.ListTrash(branch)
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if len(entries) == 0 {
+			utils.Error("No backup found for '%s'. Use `dflow trash list` to see deleted branches.", branch)
+			return nil
+		}
+
+		entry := entries[0]
+		if at != "" {
+			found := false
+			for _, e := range entries {
+				if e.ID() == at {
+					entry, found = e, true
+					break
+				}
+			}
+			if !found {
+				utils.Error("No backup of '%s' at '%s'", branch, at)
+				return nil
+			}
+		}
+
+		name := branch
+		if as != "" {
+			name = as
+		}
+
+		if valid, reason := validators.IsValidGitBranchName(name); !valid {
+			utils.Error("Invalid branch name '%s': %s", name, reason)
+			return nil
+		}
+
+		if err := gitutils.RestoreFromTrash(entry, name); err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		return nil
+	}),
+}
+
+func init() {
+	RestoreCmd.Flags().String("at", "", "Restore the backup with this timestamp instead of the latest one")
+	RestoreCmd.Flags().String("as", "", "Restore under a different branch name")
+
+	RestoreCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		entries, _ := gitutils.ListTrash("")
+		seen := make(map[string]bool)
+		var branches []string
+		for _, entry := range entries {
+			if !seen[entry.Branch] {
+				seen[entry.Branch] = true
+				branches = append(branches, entry.Branch)
+			}
+		}
+		return branches, cobra.ShellCompDirectiveNoFileComp
+	}
+}
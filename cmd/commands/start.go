@@ -8,12 +8,16 @@ package commands
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
 	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/lifecycle"
 	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
 	"github.com/yepizrene-devoost/dflow/pkg/validators"
 )
 
@@ -25,22 +29,56 @@ import (
 //   - release      : Creates a release branch from `flow.release_base`
 //   - fix|hot|hotfix       : Creates a hotfix branch from `flow.hotfix_base`
 //   - bug|bugfix       : Creates a bugfix branch from `flow.bugfix_base`
+//   - support      : Creates a long-lived support branch (support/1.x) from a release tag
 //
 // Branches are automatically prefixed using values from `.dflow.yaml`
-// under `branches.features`, `branches.releases`, or `branches.hotfixes`.
+// under `branches.features`, `branches.releases`, or `branches.hotfixes`, and built
+// from the naming template of their type (`naming.<type>.template`), whose policy
+// (ticket pattern, name pattern, max length) is enforced before the branch is created.
 //
 // This command performs the following steps:
-//  1. Checks out the appropriate base branch
-//  2. Pulls the latest changes from origin
-//  3. Creates and checks out the new branch
-//  4. Prompts the user to push the new branch to origin
+//  1. Refuses to run from a detached HEAD or during a merge/rebase
+//  2. Offers to stash, carry over or abort when the working tree is dirty
+//  3. Fast-forwards the base branch (or the one given with `--base`) to origin,
+//     without checking it out (see `dflow update`)
+//  4. Creates and checks out the new branch from it (re-applying any stash)
+//  5. Records the flow metadata (type, base, start commit, author, ticket) under
+//     `branch.<name>.dflow-*` for later commands such as `dflow status`
+//  6. Prompts the user to push the new branch to origin
+//
+// The pre-start and post-start lifecycle hooks run around the creation of the branch,
+// and pre-publish and post-publish around the push (see package lifecycle).
+//
+// Releases and hotfixes started without a name are named after their next version
+// (see `versioning.scheme`): the next minor or calendar version for releases, the next
+// patch of the latest release on the hotfix base for hotfixes. When `versioning.scheme`
+// is set, release names must be versions of that scheme. Once a release branch is created,
+// the files listed under `version_files` are rewritten to its version and committed.
+//
+// With `--from <ref>`, step 3 is skipped and the branch starts at the given
+// tag, commit or branch instead. Support branches named after a version line start from
+// the latest release of that line (v1.4.2 for support/1.x) unless `--from` is given.
+//
+// With `--support <line>`, a hotfix starts from the support branch of that line, is named
+// after its next patch and merges back into it when finished.
+//
+// With `--worktree` (or `worktrees.enabled` in .dflow.yaml), the branch is created in a
+// new `git worktree` under `worktrees.dir` and the current checkout is left untouched.
 //
 // Example usage:
 //
 //	dflow start feat login-form
 //	dflow start release v1.0.0
+//	dflow start release
 //	dflow start hotfix urgent-patch
+//	dflow start hotfix
 //	dflow start bug bug-on-uat-detected
+//	dflow start feat login-form --autostash
+//	dflow start bug broken-checkout --from v1.4.2
+//	dflow start bug broken-checkout --base develop
+//	dflow start hotfix urgent-patch --worktree
+//	dflow start support 1.x
+//	dflow start hotfix --support 1.x
 //
 // If arguments are missing, help text is shown instead.
 var StartCmd = &cobra.Command{
@@ -53,34 +91,56 @@ var StartCmd = &cobra.Command{
     - release	: Starts a new release branch from the configured 'release_base'
     - fix|hot|hotfix	: Starts a new hotfix branch from the configured 'hotfix_base'
     - bug|bugfix	: Starts a new bugfix branch from the configured 'bugfix_base'
+    - support	: Starts a support branch for an older version line from a release tag
 
   Examples:
     dflow start feat login-form
     dflow start release v1.0.0
+    dflow start release              (named after the next version)
     dflow start hotfix urgent-patch
+    dflow start hotfix               (named after the next patch of the latest release)
     dflow start bug bug-on-uat-detected
+    dflow start feat login-form --autostash
+    dflow start bug broken-checkout --from v1.4.2
+    dflow start bug broken-checkout --base develop
+    dflow start hotfix urgent-patch --worktree
+    dflow start support 1.x          (from the latest 1.x release tag)
+    dflow start hotfix --support 1.x (next 1.x patch, merged back into support/1.x)
+
+  Use --base <branch> to start from a different branch than the configured base; the branch
+  is then merged back into it. Use --from <ref> to start from a tag, commit or any other ref.
+  Use --worktree to create the branch in a new git worktree (see 'worktrees.dir' in .dflow.yaml)
+  and keep your current checkout as it is.
+
+  If the working tree has uncommitted changes, you can stash them (they are re-applied
+  on the new branch), carry them over, or abort. Use --autostash to stash without asking.
 
   The new branch will be created using the appropriate prefix (e.g., feature/, release/, hotfix/, bugfix/)
-  and based on the corresponding base branch defined in your .dflow.yaml configuration.`,
-	DisableFlagParsing: true,
+  and based on the corresponding base branch defined in your .dflow.yaml configuration.
 
-	Args: cobra.MinimumNArgs(2),
+  Release names must follow 'versioning.scheme' when it is set (semver, calver or free).
+  New releases bump the files listed under 'version_files' to their version in a commit.
+
+  Names follow the per-type templates under 'naming' in .dflow.yaml (e.g. '{prefix}{ticket}-{slug}').
+  When a template requires a ticket and --ticket is not given, you are prompted for it.`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
 
-		if len(args) < 2 {
+		branchType, ok := utils.ResolveBranchType(args[0])
+		if len(args) < 2 && (!ok || (branchType != utils.TypeRelease && branchType != utils.TypeHotfix)) {
 			_ = cmd.Help()
 			return nil
 		}
 
-		branchType := args[0]
-
-		//normalize name of branch, change "word with word" or multiple void spaaces to "word-with-word"
-		branchNameParts := strings.Fields(strings.Join(args[1:], " "))
-		branchName := strings.Join(branchNameParts, "-")
+		autostash, _ := cmd.Flags().GetBool("autostash")
+		ticket, _ := cmd.Flags().GetString("ticket")
+		baseOverride, _ := cmd.Flags().GetString("base")
+		from, _ := cmd.Flags().GetString("from")
+		support, _ := cmd.Flags().GetString("support")
 
-		if strings.HasPrefix(branchName, "-") {
-			utils.Warn("Branch name appears to start with '-'.")
-			utils.Info("If your branch name starts with '-', wrap it in quotes or use '--' to avoid flag parsing issues.")
+		if baseOverride != "" && from != "" {
+			utils.Error("--base and --from cannot be used together")
+			return nil
 		}
 
 		cfg, err := utils.LoadConfig()
@@ -89,49 +149,204 @@ var StartCmd = &cobra.Command{
 			return nil
 		}
 
-		var prefix, base string
-
-		switch branchType {
-		case "feat", "feature":
-			prefix = cfg.Branches.Features
-			base = cfg.Flow.FeatureBase
-		case "release":
-			prefix = cfg.Branches.Releases
-			base = cfg.Flow.ReleaseBase
-		case "hot", "hotfix":
-			prefix = cfg.Branches.Hotfixes
-			base = cfg.Flow.HotfixBase
-		case "bug", "bugfix":
-			prefix = cfg.Branches.Bugfixes
-			base = cfg.Flow.BugfixBase
-		default:
-			utils.Error("Unknown type. Use: feat, release, hotfix")
+		useWorktree := cfg.Worktrees.Enabled
+		if cmd.Flags().Changed("worktree") {
+			useWorktree, _ = cmd.Flags().GetBool("worktree")
+		}
+
+		// a worktree leaves the current checkout alone, so its state doesn't matter
+		if !useWorktree {
+			if err := validators.EnsureOnBranch(); err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+
+			if err := validators.EnsureNoOperationInProgress(); err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+		}
+
+		if !ok {
+			utils.Error("Unknown type. Use: feat, release, hotfix, bugfix, support")
 			return nil
 		}
 
-		fullName := fmt.Sprintf("%s%s", prefix, branchName)
+		// 🛟 hotfixes of an older version line start from its support branch and merge back into it
+		if support != "" {
+			if branchType != utils.TypeHotfix {
+				utils.Error("--support only applies to hotfixes")
+				return nil
+			}
+			if baseOverride != "" || from != "" {
+				utils.Error("--support cannot be used together with --base or --from")
+				return nil
+			}
+			support = utils.SupportBranch(cfg, support)
+			if !gitutils.LocalBranchExists(support) && !gitutils.RefExists("refs/remotes/origin/"+support) {
+				utils.Error("Support branch '%s' does not exist locally or on origin. Start it with `dflow start support`.", support)
+				return nil
+			}
+			baseOverride = support
+		}
+		if branchType == utils.TypeSupport && baseOverride != "" {
+			utils.Error("Support branches start from a tag: use --from instead of --base")
+			return nil
+		}
 
-		if valid, reason := validators.IsValidGitBranchName(fullName); !valid {
-			utils.Error("Invalid branch name '%s': %s", fullName, reason)
+		// 🔢 releases and hotfixes without a name are named after their next version
+		name := strings.Join(args[1:], " ")
+		if name == "" {
+			if name, err = nextVersionName(cfg, branchType, baseOverride); err != nil {
+				utils.Error("Cannot generate the %s name: %v. Pass it explicitly.", branchType, err)
+				return nil
+			}
+			utils.Info("Next %s version: %s", branchType, name)
+		}
+
+		//normalize name of branch, e.g. "Añadir pantalla de éxito!" to "anadir-pantalla-de-exito"
+		branchName := utils.Slugify(cfg, name)
+		if branchName == "" {
+			utils.Error("Branch name '%s' is empty once normalized", name)
 			return nil
 		}
 
-		if err := gitutils.Checkout(base); err != nil {
-			utils.Error("Could not checkout base branch '%s'", base)
+		if branchType == utils.TypeRelease && cfg.Versioning.Scheme != "" {
+			if err := versioning.ValidateReleaseName(cfg, branchName); err != nil {
+				utils.Error("Release name rejected by the %s scheme: %v", utils.GetVersionScheme(cfg), err)
+				return nil
+			}
+		}
+
+		prefix := utils.PrefixForType(cfg, branchType)
+		base := utils.BaseForType(cfg, branchType)
+
+		if support != "" {
+			if tag, isVersion := versioning.ReleaseTag(cfg, prefix+branchName, branchType); isVersion && !utils.InSupportLine(cfg, support, tag) {
+				utils.Error("%s is not a version of the line of '%s'", tag, support)
+				return nil
+			}
+		}
+
+		// support branches start from the latest release of their line by default
+		if branchType == utils.TypeSupport && from == "" {
+			if from, err = versioning.SupportStartTag(cfg, utils.SupportBranch(cfg, branchName)); err != nil {
+				utils.Error("%v. Pass the tag to start from with --from.", err)
+				return nil
+			}
+			utils.Info("Starting from the latest release of the line: %s", from)
+		}
+
+		if baseOverride != "" {
+			if !gitutils.LocalBranchExists(baseOverride) && !gitutils.RefExists("refs/remotes/origin/"+baseOverride) {
+				utils.Error("Base branch '%s' does not exist locally or on origin", baseOverride)
+				return nil
+			}
+			base = baseOverride
+		}
+
+		if from != "" {
+			if !gitutils.RefExists(from + "^{commit}") {
+				utils.Error("'%s' is not an existing branch, tag or commit", from)
+				return nil
+			}
+			base = from
+		}
+
+		// 🏷️ build the name from the type's naming template, asking for a ticket if it needs one
+		rule := utils.GetNamingRule(cfg, branchType)
+		if rule.RequiresTicket() && ticket == "" {
+			err = survey.AskOne(&survey.Input{Message: "Ticket ID:"}, &ticket, survey.WithValidator(func(ans interface{}) error {
+				value, _ := ans.(string)
+				return validators.ValidateTicket(rule, strings.TrimSpace(value))
+			}))
+			if err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+			ticket = strings.TrimSpace(ticket)
+		}
+
+		fullName := utils.RenderBranchName(rule.Template, prefix, branchType, ticket, branchName)
+
+		if err := validators.ValidateBranchPolicy(rule, fullName, branchName, ticket); err != nil {
+			utils.Error("Branch name rejected by naming policy: %v", err)
 			return nil
 		}
 
-		if err := gitutils.Pull(); err != nil {
-			utils.Error("Failed to pull latest changes from '%s'", base)
+		if valid, reason := validators.IsValidGitBranchName(fullName); !valid {
+			utils.Error("Invalid branch name '%s': %s", fullName, reason)
 			return nil
 		}
 
-		if err := gitutils.CheckoutNew(fullName); err != nil {
-			utils.Error("Failed to create branch '%s'", fullName)
+		target := baseOverride
+		if target == "" {
+			target = utils.MergeTargetForType(cfg, branchType)
+		}
+		hookCtx := lifecycle.Context{Type: branchType, Branch: fullName, Base: base, Target: target}
+		if !lifecycle.RunPre(cfg, utils.HookPreStart, hookCtx) {
 			return nil
 		}
 
-		utils.Success("Created and switched to branch '%s' from '%s'", fullName, base)
+		stashed := false
+		worktreePath := ""
+
+		if useWorktree {
+			worktreePath, err = createWorktree(cfg, fullName, base, from)
+			if err != nil {
+				utils.Error(err.Error())
+				return nil
+			}
+			utils.Success("Created branch '%s' from '%s' in worktree %s", fullName, base, worktreePath)
+			utils.Info("Switch to the new worktree with: cd %s", worktreePath)
+		} else {
+			var ok bool
+			if stashed, ok = checkoutNewBranch(fullName, base, from, autostash); !ok {
+				return nil
+			}
+			utils.Success("Created and switched to branch '%s' from '%s'", fullName, base)
+		}
+
+		// 📝 remember how the branch was started so later commands don't depend on prefixes
+		meta := gitutils.BranchMeta{
+			Type:        branchType,
+			Base:        base,
+			Target:      baseOverride,
+			StartCommit: gitutils.HeadCommit(fullName),
+			Author:      gitutils.CurrentAuthor(),
+			Ticket:      ticket,
+			Created:     time.Now().Format(time.RFC3339),
+			Worktree:    worktreePath,
+		}
+		if err := gitutils.SaveBranchMeta(fullName, meta); err != nil {
+			utils.Warn("Could not record flow metadata: %v", err)
+		}
+
+		// 🔢 releases ship the version they are named after in the version files
+		if branchType == utils.TypeRelease && len(cfg.VersionFiles) > 0 {
+			dir := worktreePath
+			if dir == "" {
+				dir, _ = gitutils.RepoRoot()
+			}
+			if !bumpVersionFiles(cfg, fullName, branchType, dir) {
+				utils.Warn("'%s' was created without the version bump. Fix the problem above and commit the bump yourself.", fullName)
+				if stashed {
+					utils.Warn("Your changes are still stashed. Run `git stash pop` to recover them.")
+				}
+				return nil
+			}
+		}
+
+		if stashed {
+			if err := gitutils.StashPop(); err != nil {
+				utils.Error(err.Error())
+				utils.Warn("Resolve the conflicts, then run `git stash drop` once your changes are restored.")
+			} else {
+				utils.Success("Re-applied stashed changes on '%s'", fullName)
+			}
+		}
+
+		lifecycle.RunPost(cfg, utils.HookPostStart, hookCtx)
 
 		// Ask to push
 		var pushBranch bool
@@ -145,7 +360,7 @@ var StartCmd = &cobra.Command{
 		}
 
 		if pushBranch {
-			if err := gitutils.PushBranch(fullName); err != nil {
+			if err := publishBranch(cfg, hookCtx); err != nil {
 				utils.Error("Failed to push branch '%s': %v", fullName, err)
 				return err
 			}
@@ -155,7 +370,201 @@ var StartCmd = &cobra.Command{
 	}),
 }
 
+// nextVersionName returns the version a new release or hotfix branch is named after when
+// no name is given (see versioning.NextRelease and versioning.NextHotfix). Hotfixes follow
+// the releases of base when it is set, e.g. a support branch.
+func nextVersionName(cfg *utils.Config, branchType string, base string) (string, error) {
+	if branchType == utils.TypeHotfix {
+		if base == "" {
+			base = utils.BaseForType(cfg, utils.TypeHotfix)
+		}
+		return versioning.NextHotfix(cfg, base)
+	}
+	return versioning.NextRelease(cfg, time.Now())
+}
+
+// bumpVersionFiles rewrites the `version_files` of the working tree at dir to the version
+// branch is named after, and commits them with `versioning.bump_message`. Version files
+// with uncommitted changes are refused rather than committed along. Failures are reported
+// to the user and return false.
+func bumpVersionFiles(cfg *utils.Config, branch string, branchType string, dir string) bool {
+	tag, ok := versioning.ReleaseTag(cfg, branch, branchType)
+	if !ok {
+		utils.Warn("'%s' is not named after a version; the version files were not bumped.", branch)
+		return true
+	}
+	version := strings.TrimPrefix(tag, utils.GetTagPrefix(cfg))
+
+	// uncommitted edits of a version file would end up in the bump commit
+	var paths []string
+	for _, file := range cfg.VersionFiles {
+		paths = append(paths, file.Path)
+	}
+	if dirty := gitutils.DirtyPaths(dir, paths); len(dirty) > 0 {
+		utils.Error("%s has uncommitted changes that would be committed with the version bump. Commit or stash them first.", strings.Join(dirty, ", "))
+		return false
+	}
+
+	changed, err := versioning.BumpFiles(cfg, dir, version)
+	if err != nil {
+		utils.Error("Failed to bump the version files: %v", err)
+		return false
+	}
+	if len(changed) == 0 {
+		utils.Info("Version files are already at %s", version)
+		return true
+	}
+
+	if err := gitutils.CommitPaths(dir, changed, utils.GetBumpMessage(cfg, version, branch, branchType)); err != nil {
+		utils.Error(err.Error())
+		return false
+	}
+	utils.Success("Bumped %s to %s", strings.Join(changed, ", "), version)
+	return true
+}
+
+// publishBranch pushes the flow branch described by hookCtx to origin, running the
+// pre-publish and post-publish hooks around the push.
+func publishBranch(cfg *utils.Config, hookCtx lifecycle.Context) error {
+	if !lifecycle.RunPre(cfg, utils.HookPrePublish, hookCtx) {
+		return fmt.Errorf("publishing was aborted by the %s hook", utils.HookPrePublish)
+	}
+
+	if err := gitutils.PushBranch(hookCtx.Branch); err != nil {
+		return err
+	}
+
+	lifecycle.RunPost(cfg, utils.HookPostPublish, hookCtx)
+	return nil
+}
+
+// checkoutNewBranch creates branch from the latest base (or from the given ref) and
+// switches to it, handling uncommitted changes first: they are stashed, carried over,
+// or the operation is aborted, depending on the user's choice or autostash.
+//
+// It reports failures to the user and returns ok=false when the branch was not created.
+// stashed tells whether the changes were stashed and must be re-applied by the caller.
+func checkoutNewBranch(branch string, base string, from string, autostash bool) (stashed bool, ok bool) {
+	// 🧺 deal with uncommitted changes before switching branches
+	if gitutils.IsDirty() {
+		choice := stashChoice
+		if !autostash {
+			utils.Warn("You have uncommitted changes.")
+			err := survey.AskOne(&survey.Select{
+				Message: "What do you want to do with them?",
+				Options: []string{stashChoice, carryChoice, abortChoice},
+				Default: stashChoice,
+			}, &choice)
+			if err != nil {
+				utils.Error(err.Error())
+				return false, false
+			}
+		}
+
+		switch choice {
+		case stashChoice:
+			if err := gitutils.Stash(fmt.Sprintf("dflow: autostash before starting %s", branch)); err != nil {
+				utils.Error(err.Error())
+				return false, false
+			}
+			stashed = true
+			utils.Info("Stashed uncommitted changes.")
+		case carryChoice:
+			utils.Info("Carrying uncommitted changes over to '%s'.", branch)
+		default:
+			fmt.Println("🚫 Operation aborted by user.")
+			return false, false
+		}
+	}
+
+	fail := func(format string, args ...interface{}) (bool, bool) {
+		utils.Error(format, args...)
+		if stashed {
+			utils.Warn("Your changes are still stashed. Run `git stash pop` to recover them.")
+		}
+		return stashed, false
+	}
+
+	if from != "" {
+		if err := gitutils.CheckoutNewFrom(branch, from); err != nil {
+			return fail("Failed to create branch '%s' from '%s'", branch, from)
+		}
+		return stashed, true
+	}
+
+	if !updateBase(base) {
+		return fail("Reconcile '%s' with origin (see `dflow update`) before starting from it", base)
+	}
+
+	if err := gitutils.CheckoutNewFrom(branch, base); err != nil {
+		return fail("Failed to create branch '%s'", branch)
+	}
+
+	return stashed, true
+}
+
+// createWorktree creates branch in a new worktree under the configured worktree directory
+// and returns its path. The branch starts at from when given, otherwise at base after
+// fast-forwarding it to origin.
+func createWorktree(cfg *utils.Config, branch string, base string, from string) (string, error) {
+	root, err := gitutils.RepoRoot()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(utils.GetWorktreeDir(cfg, root), strings.ReplaceAll(branch, "/", "-"))
+
+	startPoint := from
+	if startPoint == "" {
+		if !updateBase(base) {
+			return "", fmt.Errorf("reconcile '%s' with origin (see `dflow update`) before starting from it", base)
+		}
+		startPoint = base
+	}
+
+	if err := gitutils.AddWorktree(path, branch, startPoint); err != nil {
+		return "", err
+	}
+
+	return path, nil
+}
+
+// Options offered when `dflow start` finds uncommitted changes.
+const (
+	stashChoice = "stash (re-apply them on the new branch)"
+	carryChoice = "carry them over as they are"
+	abortChoice = "abort"
+)
+
 func init() {
+	StartCmd.Flags().Bool("autostash", false, "Stash uncommitted changes and re-apply them on the new branch without asking")
+	StartCmd.Flags().String("base", "", "Start from this branch instead of the configured base (and merge back into it)")
+	StartCmd.Flags().String("from", "", "Start from a specific tag, commit or ref")
+	StartCmd.Flags().String("support", "", "Start a hotfix from a support branch (e.g. 1.x) and merge it back there")
+	StartCmd.Flags().Bool("worktree", false, "Create the branch in a new git worktree instead of switching the current checkout")
+	StartCmd.Flags().String("ticket", "", "Ticket ID to record with the branch (e.g. PAY-123)")
+
+	_ = StartCmd.RegisterFlagCompletionFunc("base", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	})
+	_ = StartCmd.RegisterFlagCompletionFunc("from", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return append(gitutils.GetTags(), gitutils.GetLocalBranches()...), cobra.ShellCompDirectiveNoFileComp
+	})
+
+	_ = StartCmd.RegisterFlagCompletionFunc("support", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var lines []string
+		for _, branch := range gitutils.GetLocalBranches() {
+			if utils.BranchTypeFor(cfg, branch) == utils.TypeSupport {
+				lines = append(lines, strings.TrimPrefix(branch, utils.PrefixForType(cfg, utils.TypeSupport)))
+			}
+		}
+		return lines, cobra.ShellCompDirectiveNoFileComp
+	})
+
 	StartCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		if len(args) == 0 {
 			return []string{
@@ -163,9 +572,11 @@ func init() {
 				"feature\tStart a new feature branch",
 				"release\tStart a new release branch",
 				"hot\tAlias for 'hotfix'",
+				"fix\tAlias for 'hotfix'",
 				"hotfix\tStart a new hotfix branch",
 				"bugfix\tStart a new bugfix branch",
 				"bug\tAlias for 'bugfix'",
+				"support\tStart a support branch for an older version line",
 			}, cobra.ShellCompDirectiveNoFileComp
 		}
 		return nil, cobra.ShellCompDirectiveNoFileComp
@@ -0,0 +1,93 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// StatusCmd shows the flow metadata of the current branch (or the given one).
+//
+// The metadata is recorded by `dflow start` under `branch.<name>.dflow-*`. For branches
+// created without dflow, the type and base are inferred from the configured prefixes.
+//
+// Example usage:
+//
+//	dflow status
+//	dflow status feature/login-form
+var StatusCmd = &cobra.Command{
+	Use:   "status [branch]",
+	Short: "Show the flow information of a branch",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		branch := gitutils.CurrentBranch()
+		if len(args) > 0 {
+			branch = args[0]
+		}
+
+		if branch == "" {
+			utils.Error("HEAD is detached. Pass a branch name to inspect it.")
+			return nil
+		}
+
+		if !gitutils.LocalBranchExists(branch) {
+			utils.Error("Branch '%s' does not exist locally", branch)
+			return nil
+		}
+
+		meta := gitutils.ResolveBranchMeta(cfg, branch)
+		if meta.Type == "" {
+			if utils.IsProtectedBranch(cfg, branch) {
+				utils.Info("'%s' is a base branch (merge mode: %s)", branch, utils.GetMergeModeForBranch(cfg, branch))
+			} else {
+				utils.Warn("'%s' is not a dflow branch and has no recorded flow metadata", branch)
+			}
+			return nil
+		}
+
+		fmt.Printf("🌿 Branch:   %s\n", branch)
+		fmt.Printf("   Type:     %s\n", meta.Type)
+		fmt.Printf("   Base:     %s\n", meta.Base)
+		if meta.Target != "" {
+			fmt.Printf("   Target:   %s\n", meta.Target)
+		}
+		if meta.StartCommit != "" {
+			fmt.Printf("   Started:  %.7s (%d commit(s) since)\n", meta.StartCommit, gitutils.CommitCount(meta.StartCommit, branch))
+		}
+		if meta.Author != "" {
+			fmt.Printf("   Author:   %s\n", meta.Author)
+		}
+		if meta.Ticket != "" {
+			fmt.Printf("   Ticket:   %s\n", meta.Ticket)
+		}
+		if meta.Created != "" {
+			fmt.Printf("   Created:  %s\n", meta.Created)
+		}
+		if worktree := gitutils.Worktrees()[branch]; worktree != "" {
+			fmt.Printf("   Worktree: %s\n", worktree)
+		}
+
+		return nil
+	}),
+}
+
+func init() {
+	StatusCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
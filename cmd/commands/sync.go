@@ -0,0 +1,229 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// SyncCmd updates the current flow branch with the latest state of its base.
+//
+// The base comes from the metadata recorded by `dflow start` (or the configured flow),
+// and is integrated with the strategy configured for the branch type under
+// `workflow.sync_strategy` (merge by default). It will:
+//
+//  1. Fetch the base branch from origin
+//  2. Merge it into, or rebase the branch onto, origin/<base>
+//  3. Stop on conflicts so they can be resolved and resumed with `--continue` or undone with `--abort`
+//  4. Offer to force-push with lease when a published branch was rebased
+//
+// Example usage:
+//
+//	dflow sync
+//	dflow sync --strategy rebase --push
+//	dflow sync --continue
+//	dflow sync --abort
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Update the current branch with its base via merge or rebase",
+	Long: `Update the current flow branch with the latest changes of its base branch.
+
+  The base is the branch it was started from (see 'dflow status'). The strategy is taken
+  from 'workflow.sync_strategy' in .dflow.yaml for the branch type (merge by default) and
+  can be overridden with --strategy.
+
+  Examples:
+    dflow sync
+    dflow sync --strategy rebase --push
+    dflow sync --continue
+    dflow sync --abort
+
+  On conflicts, resolve them, stage the files with 'git add' and run 'dflow sync --continue',
+  or run 'dflow sync --abort' to return to the previous state.`,
+	Args: cobra.NoArgs,
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		strategy, _ := cmd.Flags().GetString("strategy")
+		baseOverride, _ := cmd.Flags().GetString("base")
+		resume, _ := cmd.Flags().GetBool("continue")
+		abort, _ := cmd.Flags().GetBool("abort")
+		push, _ := cmd.Flags().GetBool("push")
+
+		// HEAD is detached while a rebase is stopped, so resolve the branch afterwards
+		operation := gitutils.OperationInProgress()
+
+		if abort {
+			if operation == "" {
+				utils.Info("There is no merge or rebase in progress.")
+				return nil
+			}
+			if err := gitutils.AbortOperation(operation); err != nil {
+				utils.Error("Failed to abort the %s: %v", operation, err)
+				return nil
+			}
+			utils.Success("Aborted the %s; '%s' is back to its previous state", operation, gitutils.CurrentBranch())
+			return nil
+		}
+
+		if resume {
+			if operation == "" {
+				utils.Info("There is no merge or rebase in progress.")
+				return nil
+			}
+			if err := gitutils.ContinueOperation(operation); err != nil {
+				reportConflicts(operation, "dflow sync")
+				return nil
+			}
+			branch := gitutils.CurrentBranch()
+			utils.Success("Completed the %s of '%s'", operation, branch)
+			return offerPush(branch, operation == "rebase", push)
+		}
+
+		if err := validators.EnsureOnBranch(); err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		branch := gitutils.CurrentBranch()
+
+		if err := validators.EnsureNoOperationInProgress(); err != nil {
+			utils.Error(err.Error())
+			utils.Info("Use `dflow sync --continue` or `dflow sync --abort`.")
+			return nil
+		}
+
+		if gitutils.IsDirty() {
+			utils.Error("You have uncommitted changes. Commit or stash them before syncing.")
+			return nil
+		}
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		meta := gitutils.ResolveBranchMeta(cfg, branch)
+		if meta.Type == "" {
+			utils.Error("'%s' is not a dflow branch. Switch to a feature, release, hotfix or bugfix branch.", branch)
+			return nil
+		}
+
+		base := meta.Base
+		if baseOverride != "" {
+			base = baseOverride
+		}
+
+		if !gitutils.LocalBranchExists(base) && !gitutils.RemoteBranchExists(base) {
+			utils.Error("'%s' was started from '%s', which is not a branch. Use --base to choose one.", branch, base)
+			return nil
+		}
+
+		if strategy == "" {
+			strategy = utils.GetSyncStrategy(cfg, meta.Type)
+		}
+		if strategy != utils.SyncMerge && strategy != utils.SyncRebase {
+			utils.Error("Unknown sync strategy '%s'. Use: merge, rebase", strategy)
+			return nil
+		}
+
+		// 🔄 fetch the base so we integrate what is on origin, not a stale local copy
+		ref := base
+		spinner := utils.NewSpinner(fmt.Sprintf("Fetching '%s' from origin...", base))
+		spinner.Start()
+		if err := gitutils.FetchRemoteBranch(base); err != nil {
+			spinner.Stop(fmt.Sprintf("Could not fetch '%s'; using the local branch", base), "⚠️")
+		} else {
+			ref = "origin/" + base
+			spinner.Stop(fmt.Sprintf("Fetched '%s'", base))
+		}
+
+		if gitutils.CommitCount("HEAD", ref) == 0 {
+			utils.Success("'%s' is already up to date with '%s'", branch, ref)
+			return nil
+		}
+
+		utils.Info("Integrating '%s' into '%s' (%s)", ref, branch, strategy)
+
+		if strategy == utils.SyncRebase {
+			err = gitutils.RebaseOnto(ref)
+		} else {
+			err = gitutils.MergeRef(ref)
+		}
+
+		if err != nil {
+			if op := gitutils.OperationInProgress(); op != "" {
+				reportConflicts(op, "dflow sync")
+				return nil
+			}
+			utils.Error("Failed to %s '%s': %v", strategy, ref, err)
+			return nil
+		}
+
+		utils.Success("'%s' is up to date with '%s'", branch, ref)
+		return offerPush(branch, strategy == utils.SyncRebase, push)
+	}),
+}
+
+// reportConflicts explains how to resume or abort an interrupted operation started
+// by the given dflow command.
+func reportConflicts(operation string, command string) {
+	utils.Warn("The %s stopped because of conflicts.", operation)
+	utils.Info("Resolve them, stage the files with `git add`, then run `%s --continue`.", command)
+	utils.Info("To give up and restore the previous state, run `%s --abort`.", command)
+}
+
+// offerPush publishes branch after it was synced. Rewritten (rebased) branches that are
+// already published need a force-push with lease; it is done without asking when
+// push is true and otherwise offered interactively.
+func offerPush(branch string, rewritten bool, push bool) error {
+	if !rewritten {
+		if push {
+			return gitutils.PushBranch(branch)
+		}
+		return nil
+	}
+
+	if gitutils.Upstream(branch) == "" {
+		return nil
+	}
+
+	if !push {
+		err := survey.AskOne(&survey.Confirm{
+			Message: fmt.Sprintf("'%s' was rebased. Force-push it to origin with lease?", branch),
+			Default: false,
+		}, &push)
+		if err != nil || !push {
+			utils.Info("Skipping push. Run `git push --force-with-lease` when you are ready.")
+			return nil
+		}
+	}
+
+	if err := gitutils.ForcePushWithLease(branch); err != nil {
+		utils.Error(err.Error())
+	}
+	return nil
+}
+
+func init() {
+	SyncCmd.Flags().String("strategy", "", "Integration strategy: merge or rebase (defaults to workflow.sync_strategy)")
+	SyncCmd.Flags().String("base", "", "Sync with this branch instead of the recorded base")
+	SyncCmd.Flags().Bool("continue", false, "Continue after resolving conflicts")
+	SyncCmd.Flags().Bool("abort", false, "Abort an interrupted sync")
+	SyncCmd.Flags().Bool("push", false, "Push after syncing (force-with-lease when rebased)")
+
+	SyncCmd.MarkFlagsMutuallyExclusive("continue", "abort")
+
+	_ = SyncCmd.RegisterFlagCompletionFunc("strategy", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{utils.SyncMerge, utils.SyncRebase}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
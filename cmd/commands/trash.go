@@ -0,0 +1,148 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// TrashCmd is the parent command for inspecting and cleaning the backups that
+// `dflow delete` stores under refs/dflow/trash.
+//
+// Available subcommands:
+//   - list: Shows the stored backups, newest first.
+//   - purge: Removes backups older than the configured retention.
+//
+// Example usage:
+//
+//	dflow trash list
+//	dflow trash purge --older-than 7
+var TrashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage backups of deleted branches",
+	Long: `Manage the backups of branches deleted with 'dflow delete'.
+
+  Examples:
+    dflow trash list
+    dflow trash list feature/login-form
+    dflow trash purge
+    dflow trash purge --older-than 7
+    dflow trash purge --all
+
+  Retention defaults to 'trash.retention_days' in .dflow.yaml (30 days if unset).
+  Restore a backup with 'dflow restore <branch>'.`,
+}
+
+// trashListCmd prints the backups stored in the dflow trash.
+var trashListCmd = &cobra.Command{
+	Use:   "list [branch]",
+	Short: "List backups of deleted branches",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		branch := ""
+		if len(args) > 0 {
+			branch = args[0]
+		}
+
+		entries, err := gitutils.ListTrash(branch)
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if len(entries) == 0 {
+			utils.Info("The dflow trash is empty.")
+			return nil
+		}
+
+		for _, entry := range entries {
+			fmt.Printf("🗑️  %s\n", entry.Branch)
+			fmt.Printf("      at:       %s (%s)\n", entry.ID(), entry.Timestamp.Local().Format(time.RFC1123))
+			fmt.Printf("      commit:   %s %s\n", entry.Commit, entry.Subject)
+			if entry.Upstream != "" {
+				fmt.Printf("      upstream: %s\n", entry.Upstream)
+			}
+		}
+
+		return nil
+	}),
+}
+
+// trashPurgeCmd removes backups from the dflow trash according to the retention policy.
+var trashPurgeCmd = &cobra.Command{
+	Use:   "purge [branch]",
+	Short: "Remove old backups of deleted branches",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		all, _ := cmd.Flags().GetBool("all")
+		olderThan, _ := cmd.Flags().GetInt("older-than")
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if olderThan <= 0 {
+			olderThan = utils.GetTrashRetentionDays(cfg)
+		}
+
+		branch := ""
+		if len(args) > 0 {
+			branch = args[0]
+		}
+
+		entries, err := gitutils.ListTrash(branch)
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		if !all {
+			entries = gitutils.TrashOlderThan(entries, olderThan, time.Now())
+		}
+
+		purged := 0
+		for _, entry := range entries {
+			if err := gitutils.RemoveFromTrash(entry, cfg.Trash.Push); err != nil {
+				utils.Error(err.Error())
+				continue
+			}
+			purged++
+		}
+
+		if all {
+			utils.Success("Purged %d backup(s)", purged)
+		} else {
+			utils.Success("Purged %d backup(s) older than %d day(s)", purged, olderThan)
+		}
+
+		return nil
+	}),
+}
+
+func init() {
+	trashPurgeCmd.Flags().Bool("all", false, "Remove every backup regardless of age")
+	trashPurgeCmd.Flags().Int("older-than", 0, "Remove backups older than this many days (defaults to trash.retention_days)")
+
+	TrashCmd.AddCommand(trashListCmd)
+	TrashCmd.AddCommand(trashPurgeCmd)
+
+	TrashCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
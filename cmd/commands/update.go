@@ -0,0 +1,132 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// UpdateCmd fetches from origin once and fast-forwards the long-lived branches
+// (main, develop, UAT and local release and support branches) to their remote counterparts,
+// without switching the current checkout.
+//
+// Branches that have diverged from origin, or have local commits origin lacks, are
+// reported and left untouched.
+//
+// Example usage:
+//
+//	dflow update
+//	dflow update main release/1.4.0
+var UpdateCmd = &cobra.Command{
+	Use:   "update [branch...]",
+	Short: "Fast-forward base and release branches without switching checkouts",
+	Long: `Fetch from origin and fast-forward local branches to their remote counterparts.
+
+  By default, the base branches from .dflow.yaml (main, develop, uat, flow bases) and every
+  local release and support branch are updated. The working tree is only touched for the branch that
+  is currently checked out; other branches are moved directly.
+
+  Branches that diverged from origin cannot be fast-forwarded and are reported instead.
+
+  Examples:
+    dflow update
+    dflow update main release/1.4.0`,
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		branches := args
+		if len(branches) == 0 {
+			branches = updatableBranches(cfg)
+		}
+
+		if err := gitutils.Fetch(); err != nil {
+			utils.Error(err.Error())
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		var diverged []string
+		for _, branch := range branches {
+			result := gitutils.FastForward(branch)
+			fmt.Fprintf(w, "   %s %s\t%s\t%s\n", ffIcon(result.Status), result.Branch, result.Status, result.Detail)
+			if result.Status == gitutils.FFDiverged || result.Status == gitutils.FFFailed {
+				diverged = append(diverged, branch)
+			}
+		}
+		_ = w.Flush()
+
+		if len(diverged) > 0 {
+			utils.Warn("Could not fast-forward: %v. Reconcile them manually (e.g. merge or reset to origin).", diverged)
+		}
+
+		return nil
+	}),
+}
+
+// updatableBranches returns the base branches and every local release and support branch.
+func updatableBranches(cfg *utils.Config) []string {
+	branches := utils.BaseBranches(cfg)
+	for _, branch := range gitutils.GetLocalBranches() {
+		if branchType := utils.BranchTypeFor(cfg, branch); branchType == utils.TypeRelease || branchType == utils.TypeSupport {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
+
+// updateBase fetches base from origin and fast-forwards the local branch, reporting
+// the outcome. It returns false when the local branch diverged from origin and
+// should not be used as a starting point.
+func updateBase(base string) bool {
+	spinner := utils.NewSpinner(fmt.Sprintf("Updating '%s' from origin...", base))
+	spinner.Start()
+
+	if err := gitutils.FetchRemoteBranch(base); err != nil {
+		spinner.Stop(fmt.Sprintf("Could not fetch '%s'; using the local branch", base), "⚠️")
+		return true
+	}
+
+	result := gitutils.FastForward(base)
+	switch result.Status {
+	case gitutils.FFDiverged:
+		spinner.Stop(fmt.Sprintf("Local '%s' has diverged from origin (%s)", base, result.Detail), "❌")
+		return false
+	case gitutils.FFAhead, gitutils.FFSkipped, gitutils.FFFailed:
+		spinner.Stop(fmt.Sprintf("'%s' not updated: %s %s", base, result.Status, result.Detail), "⚠️")
+	default:
+		spinner.Stop(fmt.Sprintf("'%s' is %s", base, result.Status))
+	}
+	return true
+}
+
+// ffIcon returns the status icon used when printing a FastForward result.
+func ffIcon(status string) string {
+	switch status {
+	case gitutils.FFUpdated, gitutils.FFCreated, gitutils.FFUpToDate:
+		return "✅"
+	case gitutils.FFDiverged, gitutils.FFFailed:
+		return "❌"
+	}
+	return "⚠️"
+}
+
+func init() {
+	UpdateCmd.ValidArgsFunction = func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return gitutils.GetLocalBranches(), cobra.ShellCompDirectiveNoFileComp
+	}
+}
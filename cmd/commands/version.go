@@ -0,0 +1,162 @@
+// Package commands provides the CLI subcommands for dflow, enabling users to manage
+// Git branching workflows using a consistent, configurable model.
+//
+// This includes project-local configuration commands under `dflow config`,
+// allowing users to set and retrieve metadata such as author name and email
+// for use in changelogs and other automated processes.
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// ciBranchEnv lists the environment variables CI systems use for the branch being built,
+// consulted when HEAD is detached.
+var ciBranchEnv = []string{"GITHUB_HEAD_REF", "GITHUB_REF_NAME", "CI_COMMIT_REF_NAME", "BITBUCKET_BRANCH", "BUILD_SOURCEBRANCH"}
+
+// VersionCmd is the parent command for the version management commands.
+//
+// Available subcommands:
+//   - compute: Derives the build version from the latest tag, the branch and the history.
+//
+// Example usage:
+//
+//	dflow version compute
+//	dflow version compute --format json
+var VersionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Compute versions from tags and branches",
+	Long: `Manage versions from version tags and the flow branches.
+
+  Examples:
+    dflow version compute
+    dflow version compute --format env >> "$GITHUB_ENV"`,
+}
+
+// versionComputeCmd prints the build version of the current commit.
+var versionComputeCmd = &cobra.Command{
+	Use:   "compute",
+	Short: "Derive a semantic version from the latest tag, branch and commit distance",
+	Long: `Derive the build version of the current commit from the latest reachable version tag,
+  the rule of the branch type in 'versioning.rules' and the number of commits since the tag.
+  Build versions are semantic versions: the command fails with the calver and free schemes.
+
+  Default rules:
+    main       exact tag, or the next patch with the distance as build metadata (1.2.1+3)
+    develop    next minor, 1.3.0-alpha.<distance>
+    feature    next minor, 1.3.0-alpha.feature-login.<distance>
+    bugfix     next minor, 1.3.0-alpha.bugfix-crash.<distance>
+    release    version in the branch name or next minor, 1.3.0-rc.<distance>
+    hotfix     version in the branch name or next patch, 1.2.1-beta.<distance>
+    default    any other branch, next minor labeled with the branch name
+
+  Formats:
+    text   the version only
+    json   the version and the data it was derived from
+    env    DFLOW_BUILD_* variables as KEY=value lines (e.g. for $GITHUB_ENV or dotenv reports)
+    shell  the same variables as export statements, for eval
+
+  When HEAD is detached (common in CI), the branch is read from --branch or the CI
+  environment (GitHub, Gitea, GitLab, Bitbucket, Azure Pipelines).
+
+  Examples:
+    dflow version compute
+    dflow version compute --format json
+    eval "$(dflow version compute --format shell)"`,
+	Args:        cobra.NoArgs,
+	Annotations: map[string]string{utils.AnnotationNoBanner: "version"},
+	RunE: validators.WithChecks(false, func(cmd *cobra.Command, args []string) error {
+		branch, _ := cmd.Flags().GetString("branch")
+		ref, _ := cmd.Flags().GetString("ref")
+		format, _ := cmd.Flags().GetString("format")
+
+		cfg, err := utils.LoadConfig()
+		if err != nil {
+			utils.Error(err.Error())
+			os.Exit(1)
+		}
+
+		if branch == "" {
+			branch = gitutils.CurrentBranch()
+		}
+		if branch == "" {
+			branch = branchFromEnv()
+		}
+
+		result, err := versioning.Compute(cfg, branch, ref)
+		if err != nil {
+			utils.Error(err.Error())
+			os.Exit(1)
+		}
+
+		switch format {
+		case "text":
+			fmt.Println(result.Version)
+		case "json":
+			data, _ := json.MarshalIndent(result, "", "  ")
+			fmt.Println(string(data))
+		case "env", "shell":
+			for _, variable := range buildVariables(result) {
+				if format == "shell" {
+					fmt.Printf("export %s='%s'\n", variable[0], strings.ReplaceAll(variable[1], "'", `'\''`))
+				} else {
+					fmt.Printf("%s=%s\n", variable[0], variable[1])
+				}
+			}
+		default:
+			utils.Error("Unknown format '%s' (expected text, json, env or shell)", format)
+			os.Exit(1)
+		}
+		return nil
+	}),
+}
+
+// buildVariables returns the DFLOW_BUILD_* variables describing a computed version.
+func buildVariables(result versioning.Result) [][2]string {
+	return [][2]string{
+		{"DFLOW_BUILD_VERSION", result.Version},
+		{"DFLOW_BUILD_MAJOR", fmt.Sprint(result.Major)},
+		{"DFLOW_BUILD_MINOR", fmt.Sprint(result.Minor)},
+		{"DFLOW_BUILD_PATCH", fmt.Sprint(result.Patch)},
+		{"DFLOW_BUILD_PRERELEASE", result.PreRelease},
+		{"DFLOW_BUILD_METADATA", result.Build},
+		{"DFLOW_BUILD_TAG", result.Tag},
+		{"DFLOW_BUILD_BRANCH", result.Branch},
+		{"DFLOW_BUILD_DISTANCE", fmt.Sprint(result.Distance)},
+		{"DFLOW_BUILD_COMMIT", result.Commit},
+	}
+}
+
+// branchFromEnv returns the branch being built according to the CI environment.
+func branchFromEnv() string {
+	for _, name := range ciBranchEnv {
+		if value := os.Getenv(name); value != "" {
+			return strings.TrimPrefix(value, "refs/heads/")
+		}
+	}
+	return ""
+}
+
+func init() {
+	VersionCmd.AddCommand(versionComputeCmd)
+
+	versionComputeCmd.Flags().String("branch", "", "Branch whose rule applies (default: current branch or CI environment)")
+	versionComputeCmd.Flags().String("ref", "HEAD", "Commit to compute the version of")
+	versionComputeCmd.Flags().String("format", "text", "Output format: text, json, env or shell")
+
+	VersionCmd.Run = func(cmd *cobra.Command, args []string) {
+		if err := cmd.Help(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error showing help: %v\n", err)
+			os.Exit(1)
+		}
+	}
+}
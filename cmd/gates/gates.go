@@ -0,0 +1,163 @@
+// Package gates runs the quality gates configured in .dflow.yaml before a branch is merged.
+//
+// A gate is a local command such as `make test` or `golangci-lint run`. Gates run one
+// after another through the shell, with their output streamed to the terminal, and the
+// outcome of each one is reported as a Result that can be recorded as a commit trailer.
+package gates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// Trailer keys added to merge commits by `dflow finish`.
+const (
+	TrailerGate    = "Dflow-Gate"
+	TrailerSkipped = "Dflow-Gates-Skipped"
+)
+
+// Result is the outcome of running one gate.
+type Result struct {
+	Gate     utils.Gate
+	Passed   bool
+	TimedOut bool
+	Duration time.Duration
+	Err      error
+}
+
+// Trailer formats the result as a commit trailer, e.g. "Dflow-Gate: make test passed (3.2s)".
+func (r Result) Trailer() string {
+	status := "passed"
+	if r.TimedOut {
+		status = "timed out"
+	} else if !r.Passed {
+		status = "failed"
+	}
+	return fmt.Sprintf("%s: %s %s (%s)", TrailerGate, r.Gate.Label(), status, r.Duration.Round(100*time.Millisecond))
+}
+
+// Run executes the gates in order from the repository root and returns their results.
+//
+// Every gate runs even if an earlier one failed, so the report is complete; use Passed
+// to know whether the merge may go ahead.
+func Run(gates []utils.Gate, repoRoot string) []Result {
+	results := make([]Result, 0, len(gates))
+	for i, gate := range gates {
+		fmt.Printf("\n🚦 Gate %d/%d: %s\n", i+1, len(gates), gate.Label())
+		result := runGate(gate, repoRoot)
+		switch {
+		case result.Passed:
+			utils.Success("%s passed in %s", gate.Label(), result.Duration.Round(100*time.Millisecond))
+		case result.TimedOut:
+			utils.Errorf("%s timed out after %s", gate.Label(), gate.Timeout)
+		default:
+			utils.Error("%s failed: %v", gate.Label(), result.Err)
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// Passed reports whether every result passed.
+func Passed(results []Result) bool {
+	for _, result := range results {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// runGate runs a single gate, streaming its output. When it times out, every process it
+// started is killed, not only the shell.
+func runGate(gate utils.Gate, repoRoot string) Result {
+	ctx := context.Background()
+	if gate.Timeout != "" {
+		timeout, err := time.ParseDuration(gate.Timeout)
+		if err != nil {
+			return Result{Gate: gate, Err: fmt.Errorf("invalid timeout '%s'", gate.Timeout)}
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	cmd := shellCommand(ctx, gate.Run)
+	killProcessGroup(cmd)
+	cmd.WaitDelay = time.Second
+	cmd.Dir = repoRoot
+	if gate.Dir != "" {
+		cmd.Dir = gate.Dir
+		if !filepath.IsAbs(gate.Dir) {
+			cmd.Dir = filepath.Join(repoRoot, gate.Dir)
+		}
+	}
+	cmd.Env = os.Environ()
+	for key, value := range gate.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	start := time.Now()
+	err := cmd.Start()
+	if err == nil {
+		// the gate runs in a process group of its own, which Ctrl+C does not reach
+		defer utils.OnInterrupt(func() { _ = cmd.Cancel() })()
+		err = cmd.Wait()
+	}
+	result := Result{Gate: gate, Duration: time.Since(start), Err: err, Passed: err == nil}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		result.Passed, result.TimedOut = false, true
+	}
+	return result
+}
+
+// LogSkip appends a record of gates skipped with `dflow finish --skip-gates` to
+// `.git/dflow/gates-skipped.log`, so skipped checks can be audited later.
+func LogSkip(branch string, target string, author string, reason string) error {
+	gitDir, err := gitutils.GitCommonDir()
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Join(gitDir, "dflow")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create '%s': %w", dir, err)
+	}
+
+	file, err := os.OpenFile(filepath.Join(dir, "gates-skipped.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open gates log: %w", err)
+	}
+	defer file.Close()
+
+	_, err = fmt.Fprintf(file, "%s\t%s -> %s\t%s\t%s\n", time.Now().UTC().Format(time.RFC3339), branch, target, author, reason)
+	return err
+}
+
+// shellCommand runs command through the platform shell.
+func shellCommand(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// AppendTrailers adds trailers to a commit message, separated by a blank line.
+func AppendTrailers(message string, trailers []string) string {
+	if len(trailers) == 0 {
+		return message
+	}
+	return strings.TrimRight(message, "\n") + "\n\n" + strings.Join(trailers, "\n")
+}
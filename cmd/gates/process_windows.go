@@ -0,0 +1,16 @@
+//go:build windows
+
+package gates
+
+import (
+	"fmt"
+	"os/exec"
+)
+
+// killProcessGroup makes cancelling cmd kill its whole process tree, so the processes the
+// shell started do not outlive the gate.
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return exec.Command("taskkill", "/T", "/F", "/PID", fmt.Sprint(cmd.Process.Pid)).Run()
+	}
+}
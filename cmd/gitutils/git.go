@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/yepizrene-devoost/dflow/cmd/utils"
 )
@@ -70,6 +71,17 @@ func CheckoutNew(branch string) error {
 	return cmd.Run()
 }
 
+// CheckoutNewFrom creates and checks out a new branch starting at the given ref
+// (branch, tag or commit) without setting up tracking.
+//
+// It wraps `git checkout --no-track -b <branch> <startPoint>`.
+func CheckoutNewFrom(branch string, startPoint string) error {
+	cmd := exec.Command("git", "checkout", "--no-track", "-b", branch, startPoint)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
 // Pull pulls the latest changes from the remote for the current branch.
 //
 // It executes `git pull` and returns an error if the command fails.
@@ -89,44 +101,84 @@ func Pull() error {
 	return nil
 }
 
-// Delete removes the given Git branch both locally and remotely.
+// DeleteLocal removes the given branch from the local repository.
 //
-// It executes `git branch -D <branch>` to delete the local branch,
-// and `git push origin --delete <branch>` to remove the branch from the remote repository.
-//
-// If both operations succeed, it logs a success message via utils.Success.
-// Returns an error if either operation fails.
-func Delete(branch string) error {
-	spinner := utils.NewSpinner(fmt.Sprintf("Deleting branch '%s' locally and remotely...", branch))
-	spinner.Start()
-
+// It executes `git branch -D <branch>`. Callers are expected to check for unmerged
+// work beforehand (see UnmergedCommits), since this operation discards it.
+func DeleteLocal(branch string) error {
 	var stderr bytes.Buffer
 	cmd := exec.Command("git", "branch", "-D", branch)
 	cmd.Stderr = &stderr
 	cmd.Stdout = nil
 	if err := cmd.Run(); err != nil {
-		spinner.Stop("Failed to delete local branch.")
-		return fmt.Errorf("❌ failed to delete local branch '%s': %s", branch, stderr.String())
+		return fmt.Errorf("❌ failed to delete local branch '%s': %s", branch, strings.TrimSpace(stderr.String()))
 	}
 
-	if RemoteBranchExists(branch) {
-		cmd = exec.Command("git", "push", "origin", "--delete", branch)
-		cmd.Stdout = nil
-		cmd.Stderr = &stderr
-		if err := cmd.Run(); err != nil {
-			spinner.Stop("Failed to delete remote branch.")
-			return fmt.Errorf("❌ failed to delete remote branch: %s", stderr.String())
-		}
-	} else {
-		spinner.Stop(fmt.Sprintf("Branch '%s' deleted locally.", branch), "🗑️")
+	utils.Success("Branch '%s' deleted locally.", branch, "🗑️")
+	return nil
+}
+
+// DeleteRemote removes the given branch from the remote `origin`.
+//
+// It executes `git push origin --delete <branch>`. If the branch does not exist on
+// origin, it logs an informational message and returns nil.
+func DeleteRemote(branch string) error {
+	if !RemoteBranchExists(branch) {
 		utils.Info("Remote branch '%s' does not exist. Skipping remote deletion.", branch)
 		return nil
 	}
 
-	spinner.Stop(fmt.Sprintf("Branch '%s' deleted locally and remotely.", branch), "🗑️")
+	spinner := utils.NewSpinner(fmt.Sprintf("Deleting branch '%s' from origin...", branch))
+	spinner.Start()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "push", "origin", "--delete", branch)
+	cmd.Stdout = nil
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		spinner.Stop("Failed to delete remote branch.", "❌")
+		return fmt.Errorf("❌ failed to delete remote branch '%s': %s", branch, strings.TrimSpace(stderr.String()))
+	}
+
+	spinner.Stop(fmt.Sprintf("Branch '%s' deleted from origin.", branch), "🗑️")
 	return nil
 }
 
+// UnmergedCommits returns the commits reachable from ref that are not reachable from any
+// of the given branches, formatted as `<short sha> <subject>`.
+//
+// Branches that do not exist locally are ignored; their `origin/` counterparts are
+// considered as well when present. An empty result means the work in ref is safe to drop.
+func UnmergedCommits(ref string, into []string) ([]string, error) {
+	args := []string{"log", "--format=%h %s", ref, "--not"}
+	for _, branch := range into {
+		if RefExists("refs/heads/" + branch) {
+			args = append(args, "refs/heads/"+branch)
+		}
+		if RefExists("refs/remotes/origin/" + branch) {
+			args = append(args, "refs/remotes/origin/"+branch)
+		}
+	}
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unmerged commits of '%s': %w", ref, err)
+	}
+
+	return splitLines(out), nil
+}
+
+// RefExists reports whether the given ref (branch, tag, commit or full ref name)
+// resolves to an object in the local repository.
+func RefExists(ref string) bool {
+	return exec.Command("git", "rev-parse", "--verify", "--quiet", ref).Run() == nil
+}
+
+// LocalBranchExists reports whether a branch with the given name exists locally.
+func LocalBranchExists(branch string) bool {
+	return RefExists("refs/heads/" + branch)
+}
+
 // RemoteBranchExists checks if a branch exists on the remote `origin`.
 //
 // It runs `git ls-remote --heads origin <branch>` and returns true if the branch exists.
@@ -146,14 +198,182 @@ func GetLocalBranches() []string {
 		return []string{}
 	}
 
-	lines := bytes.Split(out, []byte("\n"))
+	return splitLines(out)
+}
+
+// GetRemoteBranches returns the branch names known for the remote `origin`,
+// without the `origin/` prefix.
+//
+// It reads the remote-tracking refs under refs/remotes/origin, so the result is as
+// fresh as the last fetch.
+func GetRemoteBranches() []string {
+	cmd := exec.Command("git", "for-each-ref", "--format=%(refname:lstrip=3)", "refs/remotes/origin")
+	out, err := cmd.Output()
+	if err != nil {
+		return []string{}
+	}
+
 	var branches []string
+	for _, branch := range splitLines(out) {
+		if branch != "HEAD" {
+			branches = append(branches, branch)
+		}
+	}
+
+	return branches
+}
+
+// splitLines splits command output into trimmed, non-empty lines.
+func splitLines(out []byte) []string {
+	lines := bytes.Split(out, []byte("\n"))
+	var result []string
 	for _, line := range lines {
 		trimmed := bytes.TrimSpace(line)
 		if len(trimmed) > 0 {
-			branches = append(branches, string(trimmed))
+			result = append(result, string(trimmed))
 		}
 	}
 
-	return branches
+	return result
+}
+
+// IsDirty reports whether the working tree has uncommitted changes,
+// including untracked files.
+func IsDirty() bool {
+	out, err := exec.Command("git", "status", "--porcelain").Output()
+	return err == nil && len(bytes.TrimSpace(out)) > 0
+}
+
+// DirtyPaths returns those of paths, relative to the working tree at dir, that have
+// uncommitted changes, staged or not, or are untracked.
+func DirtyPaths(dir string, paths []string) []string {
+	args := append([]string{"-C", dir, "status", "--porcelain", "-z", "--"}, paths...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil
+	}
+	var dirty []string
+	for _, entry := range strings.Split(string(out), "\x00") {
+		// "XY path"; the original path of a rename follows as an entry of its own
+		if len(entry) > 3 && entry[2] == ' ' {
+			dirty = append(dirty, entry[3:])
+		}
+	}
+	return dirty
+}
+
+// IsDetachedHead reports whether HEAD points directly to a commit instead of a branch.
+func IsDetachedHead() bool {
+	return exec.Command("git", "symbolic-ref", "--quiet", "HEAD").Run() != nil
+}
+
+// CurrentBranch returns the name of the checked-out branch, or an empty string
+// when HEAD is detached.
+func CurrentBranch() string {
+	out, err := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// UnmergedPaths returns the files of the index left with unresolved conflicts.
+func UnmergedPaths() []string {
+	out, err := exec.Command("git", "diff", "--name-only", "--diff-filter=U").Output()
+	if err != nil {
+		return nil
+	}
+	return splitLines(out)
+}
+
+// OperationInProgress returns the name of the Git operation the repository is in the
+// middle of ("merge", "rebase", "cherry-pick" or "revert"), or an empty string if none.
+func OperationInProgress() string {
+	markers := []struct {
+		path      string
+		operation string
+	}{
+		{"rebase-merge", "rebase"},
+		{"rebase-apply", "rebase"},
+		{"MERGE_HEAD", "merge"},
+		{"CHERRY_PICK_HEAD", "cherry-pick"},
+		{"REVERT_HEAD", "revert"},
+	}
+
+	for _, marker := range markers {
+		out, err := exec.Command("git", "rev-parse", "--git-path", marker.path).Output()
+		if err != nil {
+			continue
+		}
+		if _, err := os.Stat(strings.TrimSpace(string(out))); err == nil {
+			return marker.operation
+		}
+	}
+
+	return ""
+}
+
+// Stash saves uncommitted changes, including untracked files, with the given message.
+//
+// It wraps `git stash push --include-untracked -m <message>`.
+func Stash(message string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "stash", "push", "--include-untracked", "-m", message)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to stash changes: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// StashPop re-applies the most recent stash and drops it.
+//
+// If applying the stash produces conflicts, Git keeps the stash entry and an error is returned.
+func StashPop() error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "stash", "pop")
+	cmd.Stdout = nil
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to re-apply stashed changes: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// GetTags returns the tag names of the local repository, newest first.
+func GetTags() []string {
+	out, err := exec.Command("git", "tag", "--sort=-creatordate").Output()
+	if err != nil {
+		return []string{}
+	}
+	return splitLines(out)
+}
+
+// MergedTags returns the tags reachable from ref.
+func MergedTags(ref string) []string {
+	out, err := exec.Command("git", "tag", "--merged", ref).Output()
+	if err != nil {
+		return []string{}
+	}
+	return splitLines(out)
+}
+
+// SetBranchConfig stores a value under `branch.<branch>.<key>` in the local Git configuration.
+//
+// Git removes these entries automatically when the branch is deleted.
+func SetBranchConfig(branch string, key string, value string) error {
+	if err := exec.Command("git", "config", "branch."+branch+"."+key, value).Run(); err != nil {
+		return fmt.Errorf("failed to set branch.%s.%s: %w", branch, key, err)
+	}
+	return nil
+}
+
+// GetBranchConfig reads `branch.<branch>.<key>` from the Git configuration.
+// It returns an empty string if the key is not set.
+func GetBranchConfig(branch string, key string) string {
+	out, err := exec.Command("git", "config", "--get", "branch."+branch+"."+key).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
 }
@@ -0,0 +1,104 @@
+package gitutils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ManagedHookMarker identifies git hooks written by `dflow hooks install`.
+const ManagedHookMarker = "# dflow-managed hook"
+
+// ChainedHookSuffix is appended to hooks that existed before dflow installed its own.
+// The managed hook runs them first, so existing hooks keep working.
+const ChainedHookSuffix = ".dflow-chained"
+
+// HooksDir returns the directory Git runs hooks from, honoring `core.hooksPath`.
+func HooksDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--path-format=absolute", "--git-path", "hooks").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate git hooks directory: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// IsManagedHook reports whether the hook file at path was written by dflow.
+func IsManagedHook(path string) bool {
+	data, err := os.ReadFile(path)
+	return err == nil && strings.Contains(string(data), ManagedHookMarker)
+}
+
+// InstallHook writes a managed hook named name that runs `dflow hooks run <name>`.
+//
+// An existing hook that is not managed by dflow is renamed with ChainedHookSuffix and
+// called by the managed hook before dflow's checks. It returns whether a hook was chained.
+func InstallHook(dir string, name string) (bool, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return false, fmt.Errorf("❌ failed to create hooks directory '%s': %w", dir, err)
+	}
+
+	path := filepath.Join(dir, name)
+	chained := false
+	if _, err := os.Stat(path); err == nil && !IsManagedHook(path) {
+		if err := os.Rename(path, path+ChainedHookSuffix); err != nil {
+			return false, fmt.Errorf("❌ failed to keep existing hook '%s': %w", path, err)
+		}
+		chained = true
+	}
+
+	if err := os.WriteFile(path, []byte(hookScript(name)), 0755); err != nil {
+		return chained, fmt.Errorf("❌ failed to write hook '%s': %w", path, err)
+	}
+	return chained, nil
+}
+
+// UninstallHook removes the managed hook named name and puts back the hook it chained,
+// if any. Hooks not written by dflow are left untouched. It returns whether a managed
+// hook was removed and whether a chained hook was restored.
+func UninstallHook(dir string, name string) (removed bool, restored bool, err error) {
+	path := filepath.Join(dir, name)
+	if !IsManagedHook(path) {
+		return false, false, nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return false, false, fmt.Errorf("❌ failed to remove hook '%s': %w", path, err)
+	}
+
+	if _, err := os.Stat(path + ChainedHookSuffix); err == nil {
+		if err := os.Rename(path+ChainedHookSuffix, path); err != nil {
+			return true, false, fmt.Errorf("❌ failed to restore hook '%s': %w", path, err)
+		}
+		return true, true, nil
+	}
+	return true, false, nil
+}
+
+// hookScript returns the POSIX shell script of a managed hook. Git passes hook input on
+// stdin (pre-push lists the refs being pushed), so it is buffered and handed to both the
+// chained hook and dflow. When dflow is not on PATH the checks are skipped with a warning.
+func hookScript(name string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%[2]s: %[1]s
+# Installed by 'dflow hooks install'; remove with 'dflow hooks uninstall'.
+
+hook_dir=$(dirname "$0")
+input=""
+if [ "%[1]s" = "pre-push" ]; then
+    input=$(cat)
+fi
+
+if [ -x "$hook_dir/%[1]s%[3]s" ]; then
+    printf '%%s\n' "$input" | "$hook_dir/%[1]s%[3]s" "$@" || exit $?
+fi
+
+if ! command -v dflow >/dev/null 2>&1; then
+    echo "dflow: not found on PATH, skipping %[1]s checks" >&2
+    exit 0
+fi
+
+printf '%%s\n' "$input" | dflow hooks run %[1]s "$@"
+`, name, ManagedHookMarker, ChainedHookSuffix)
+}
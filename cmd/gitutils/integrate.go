@@ -0,0 +1,170 @@
+package gitutils
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// MergeRef merges ref into the current branch, keeping Git's default commit message.
+//
+// It wraps `git merge --no-edit <ref>`. On conflicts the merge is left in progress
+// (see OperationInProgress) and an error is returned.
+func MergeRef(ref string) error {
+	return runInteractive("git", "merge", "--no-edit", ref)
+}
+
+// RebaseOnto rebases the current branch onto ref.
+//
+// It wraps `git rebase <ref>`. On conflicts the rebase is left in progress
+// (see OperationInProgress) and an error is returned.
+func RebaseOnto(ref string) error {
+	return runInteractive("git", "rebase", ref)
+}
+
+// SquashConflictError is returned by MergeBranch when a squash merge stops on conflicts.
+//
+// Git records no merge in progress for squash merges: the target is left checked out with
+// the conflicted files in the index, to be resolved and committed with Message, or undone
+// with `git reset --merge`.
+type SquashConflictError struct {
+	Branch  string
+	Target  string
+	Message string   // message of the squash commit
+	Paths   []string // files with conflicts
+}
+
+func (e *SquashConflictError) Error() string {
+	return fmt.Sprintf("❌ the squash merge of '%s' into '%s' stopped on conflicts in %s",
+		e.Branch, e.Target, strings.Join(e.Paths, ", "))
+}
+
+// MergeBranch merges branch into target with the given strategy (see utils.MergeStrategies),
+// leaving target checked out.
+//
+// The message is used for the merge commit of no-ff merges and for the single commit
+// of squash merges; rebase and ff-only merges create no commit of their own. On conflicts
+// the merge or rebase is left in progress (see OperationInProgress) and an error is returned,
+// a *SquashConflictError for squash merges.
+//
+// A branch checked out in another worktree is rebased there; a target checked out in
+// another worktree cannot be merged into from here and an error is returned.
+func MergeBranch(branch string, target string, strategy string, message string) error {
+	worktrees := Worktrees()
+	if dir := worktrees[target]; dir != "" {
+		if root, err := RepoRoot(); err == nil && root != dir {
+			return fmt.Errorf("❌ '%s' is checked out in the worktree %s: merge there, or switch that worktree to another branch", target, dir)
+		}
+	}
+
+	switch strategy {
+	case utils.MergeNoFF:
+		if err := runInteractive("git", "checkout", "--quiet", target); err != nil {
+			return err
+		}
+		return runInteractive("git", "merge", "--no-ff", "-m", message, branch)
+
+	case utils.MergeSquash:
+		if err := runInteractive("git", "checkout", "--quiet", target); err != nil {
+			return err
+		}
+		if err := runInteractive("git", "merge", "--squash", branch); err != nil {
+			if paths := UnmergedPaths(); len(paths) > 0 {
+				return &SquashConflictError{Branch: branch, Target: target, Message: message, Paths: paths}
+			}
+			return err
+		}
+		return runInteractive("git", "commit", "--quiet", "-m", message)
+
+	case utils.MergeRebase:
+		if dir := worktrees[branch]; dir != "" {
+			// git refuses to rebase a branch checked out elsewhere, so rebase it in place
+			if err := runInteractive("git", "-C", dir, "rebase", target); err != nil {
+				return fmt.Errorf("❌ the rebase of '%s' failed in the worktree %s; if it stopped on conflicts, complete it there with `git rebase --continue` or `git rebase --abort`", branch, dir)
+			}
+		} else if err := runInteractive("git", "rebase", target, branch); err != nil {
+			return err
+		}
+		if err := runInteractive("git", "checkout", "--quiet", target); err != nil {
+			return err
+		}
+		return runInteractive("git", "merge", "--ff-only", branch)
+
+	case utils.MergeFFOnly:
+		if err := runInteractive("git", "checkout", "--quiet", target); err != nil {
+			return err
+		}
+		return runInteractive("git", "merge", "--ff-only", branch)
+	}
+
+	return fmt.Errorf("❌ unknown merge strategy '%s'", strategy)
+}
+
+// CommitPaths commits the given paths of the working tree at dir with message, leaving
+// other staged or modified files out of the commit.
+func CommitPaths(dir string, paths []string, message string) error {
+	add := append([]string{"-C", dir, "add", "--"}, paths...)
+	if err := runInteractive("git", add...); err != nil {
+		return fmt.Errorf("❌ failed to stage %v: %w", paths, err)
+	}
+	commit := append([]string{"-C", dir, "commit", "--quiet", "-m", message, "--"}, paths...)
+	if err := runInteractive("git", commit...); err != nil {
+		return fmt.Errorf("❌ failed to commit %v: %w", paths, err)
+	}
+	return nil
+}
+
+// CherryPick applies commits onto the current branch, in order, recording their origin in
+// the messages (`git cherry-pick -x`). On conflicts the cherry-pick is left in progress
+// (see OperationInProgress) and an error is returned.
+func CherryPick(commits []string) error {
+	return runInteractive("git", append([]string{"cherry-pick", "-x"}, commits...)...)
+}
+
+// ContinueOperation resumes an interrupted merge, rebase, cherry-pick or revert once
+// conflicts have been resolved and staged, without opening an editor.
+func ContinueOperation(operation string) error {
+	switch operation {
+	case "merge":
+		return runInteractive("git", "commit", "--no-edit")
+	case "rebase", "cherry-pick", "revert":
+		return runInteractive("git", "-c", "core.editor=true", operation, "--continue")
+	}
+	return fmt.Errorf("nothing to continue")
+}
+
+// AbortOperation aborts an interrupted merge, rebase, cherry-pick or revert and
+// restores the branch to its previous state.
+func AbortOperation(operation string) error {
+	switch operation {
+	case "merge", "rebase", "cherry-pick", "revert":
+		return runInteractive("git", operation, "--abort")
+	}
+	return fmt.Errorf("nothing to abort")
+}
+
+// ForcePushWithLease pushes branch to origin, replacing the remote history only if it
+// still matches the last fetched state (`git push --force-with-lease`).
+func ForcePushWithLease(branch string) error {
+	spinner := utils.NewSpinner(fmt.Sprintf("Force-pushing '%s' to origin...", branch))
+	spinner.Start()
+
+	cmd := exec.Command("git", "push", "--force-with-lease", "origin", branch)
+	if err := cmd.Run(); err != nil {
+		spinner.Stop("Force-push rejected.", "❌")
+		return fmt.Errorf("❌ failed to force-push '%s' (the remote may have new commits): %w", branch, err)
+	}
+	spinner.Stop(fmt.Sprintf("Force-pushed '%s' with lease", branch), "🚀")
+	return nil
+}
+
+// runInteractive runs a git command streaming its output to the terminal.
+func runInteractive(name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,235 @@
+package gitutils
+
+import (
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// BranchMeta holds the flow metadata dflow records when a branch is started.
+//
+// It is stored in the local Git configuration under `branch.<name>.dflow-*`, so it
+// follows the branch around and is removed by Git when the branch is deleted.
+type BranchMeta struct {
+	Type        string // canonical branch type (feature, release, hotfix, bugfix)
+	Base        string // branch, tag or commit the branch was started from
+	Target      string // branch to merge back into, when it differs from the flow default
+	StartCommit string // commit the branch was created at
+	Author      string // who started the branch, "Name <email>"
+	Ticket      string // optional ticket ID (e.g. PAY-123)
+	Created     string // creation time in RFC 3339 format
+	Worktree    string // worktree path, when the branch was started with --worktree
+}
+
+// metaKeys maps git config keys to the BranchMeta fields they hold.
+func metaKeys(meta *BranchMeta) map[string]*string {
+	return map[string]*string{
+		"dflow-type":     &meta.Type,
+		"dflow-base":     &meta.Base,
+		"dflow-target":   &meta.Target,
+		"dflow-start":    &meta.StartCommit,
+		"dflow-author":   &meta.Author,
+		"dflow-ticket":   &meta.Ticket,
+		"dflow-created":  &meta.Created,
+		"dflow-worktree": &meta.Worktree,
+	}
+}
+
+// SaveBranchMeta stores every non-empty field of meta under `branch.<branch>.dflow-*`.
+func SaveBranchMeta(branch string, meta BranchMeta) error {
+	for key, value := range metaKeys(&meta) {
+		if *value == "" {
+			continue
+		}
+		if err := SetBranchConfig(branch, key, *value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadBranchMeta reads the metadata recorded for branch. Missing fields are left empty.
+func LoadBranchMeta(branch string) BranchMeta {
+	var meta BranchMeta
+	for key, value := range metaKeys(&meta) {
+		*value = GetBranchConfig(branch, key)
+	}
+	return meta
+}
+
+// ResolveBranchMeta returns the recorded metadata of branch, filling the type and base
+// from the configured prefixes and flow when they were not recorded (e.g. for branches
+// created before dflow kept metadata, or by hand).
+func ResolveBranchMeta(cfg *utils.Config, branch string) BranchMeta {
+	meta := LoadBranchMeta(branch)
+	if meta.Type == "" {
+		meta.Type = utils.BranchTypeFor(cfg, branch)
+	}
+	if meta.Base == "" {
+		meta.Base = utils.BaseForType(cfg, meta.Type)
+	}
+	return meta
+}
+
+// CurrentAuthor returns the author used for dflow metadata as "Name <email>".
+//
+// It prefers the project-local `dflow.author`/`dflow.email` set with
+// `dflow config set-author`, and falls back to `user.name`/`user.email`.
+func CurrentAuthor() string {
+	get := func(keys ...string) string {
+		for _, key := range keys {
+			out, err := exec.Command("git", "config", "--get", key).Output()
+			if err == nil && len(strings.TrimSpace(string(out))) > 0 {
+				return strings.TrimSpace(string(out))
+			}
+		}
+		return ""
+	}
+
+	name := get("dflow.author", "user.name")
+	email := get("dflow.email", "user.email")
+	if email == "" {
+		return name
+	}
+	return name + " <" + email + ">"
+}
+
+// HeadCommit returns the full hash of the commit ref points to.
+func HeadCommit(ref string) string {
+	out, err := exec.Command("git", "rev-parse", "--verify", ref+"^{commit}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// CommitCount returns the number of commits in the range `from..to`.
+func CommitCount(from string, to string) int {
+	out, err := exec.Command("git", "rev-list", "--count", from+".."+to).Output()
+	if err != nil {
+		return 0
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return count
+}
+
+// Commit is a commit with its subject.
+type Commit struct {
+	Hash    string
+	Subject string
+	Merge   bool // the commit has several parents
+}
+
+// CommitsBetween returns the commits of the range `from..to` that are not merges, oldest first.
+func CommitsBetween(from string, to string) []Commit {
+	out, err := exec.Command("git", "log", "--reverse", "--no-merges", "--format=%H %s", from+".."+to).Output()
+	if err != nil {
+		return nil
+	}
+
+	var commits []Commit
+	for _, line := range splitLines(out) {
+		hash, subject, _ := strings.Cut(line, " ")
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+	return commits
+}
+
+// BranchCommits returns the commits made on a flow branch itself, oldest first and without
+// merges: its first-parent history down to the first commit that is on the first-parent
+// history of base (local or on origin), or down to start. Commits the branch got from
+// syncing with its base are left out, whether the base was merged in or the branch was
+// rebased. When the branch was fast-forwarded into base, its first-parent commits since
+// start are returned instead.
+func BranchCommits(branch string, base string, start string) []Commit {
+	stops := make(map[string]bool)
+	if start != "" {
+		stops[start] = true
+	}
+	for _, ref := range []string{"refs/heads/" + base, "refs/remotes/origin/" + base} {
+		if base == "" || !RefExists(ref) {
+			continue
+		}
+		out, err := exec.Command("git", "rev-list", "--first-parent", ref).Output()
+		if err != nil {
+			continue
+		}
+		for _, hash := range splitLines(out) {
+			stops[hash] = true
+		}
+	}
+
+	var commits []Commit
+	history := firstParentCommits(branch)
+	for i := len(history) - 1; i >= 0; i-- {
+		if stops[history[i].Hash] {
+			commits = nil
+			continue
+		}
+		if !history[i].Merge {
+			commits = append(commits, history[i])
+		}
+	}
+	if len(commits) > 0 || start == "" {
+		return commits
+	}
+
+	// the branch is part of the history of base: fall back to what it got since start
+	for _, commit := range firstParentCommits("--reverse", start+".."+branch) {
+		if !commit.Merge {
+			commits = append(commits, commit)
+		}
+	}
+	return commits
+}
+
+// firstParentCommits returns the commits of `git log` with args following first parents
+// only, newest first unless args include --reverse.
+func firstParentCommits(args ...string) []Commit {
+	args = append([]string{"log", "--first-parent", "--format=%H%x09%P%x09%s"}, args...)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil
+	}
+
+	var commits []Commit
+	for _, line := range splitLines(out) {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		commits = append(commits, Commit{Hash: fields[0], Subject: fields[2], Merge: len(strings.Fields(fields[1])) > 1})
+	}
+	return commits
+}
+
+// MainlineCommits returns the commits of the range `from..to` following first parents only,
+// oldest first: the merges of other branches into to and the commits made on it directly.
+func MainlineCommits(from string, to string) []Commit {
+	return firstParentCommits("--reverse", from+".."+to)
+}
+
+// CommitParents returns the full hashes of the parents of the commit ref points to.
+func CommitParents(ref string) []string {
+	out, err := exec.Command("git", "rev-list", "--parents", "-n", "1", ref).Output()
+	if err != nil {
+		return nil
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) == 0 {
+		return nil
+	}
+	return fields[1:]
+}
+
+// CountCommits returns the number of commits reachable from ref.
+func CountCommits(ref string) int {
+	out, err := exec.Command("git", "rev-list", "--count", ref).Output()
+	if err != nil {
+		return 0
+	}
+	count, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return count
+}
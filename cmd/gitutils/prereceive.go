@@ -0,0 +1,229 @@
+package gitutils
+
+import "strings"
+
+// PreReceiveHook returns a standalone POSIX shell pre-receive hook enforcing .dflow.yaml
+// on a Git server, for bare repositories and self-hosted servers such as Gitea.
+//
+// The hook needs only sh, awk, grep and git. For every pushed ref it reads .dflow.yaml
+// from the pushed ref itself: from the current tip of an existing branch (the rules in
+// force before the push, so a push cannot loosen its own rules), or from the pushed
+// commit for new branches and tags, falling back to the default branch. Refs without a
+// .dflow.yaml to read are accepted.
+//
+// It rejects:
+//   - branches that are neither base branches nor start with a configured prefix
+//   - direct updates to base branches and `workflow.branch_rules` entries whose merge
+//     mode is manual, unless DFLOW_ALLOW_MANUAL=1 or GITEA_PR_ID (set by Gitea when it
+//     merges a pull request) is in the environment
+//   - deletions and non-fast-forward updates of base branches
+//   - tags that match neither the version tag pattern (`versioning.tag_pattern`, or the
+//     default of `versioning.scheme`) nor the format of promotion tags (`promotion.tag_format`)
+func PreReceiveHook() string {
+	return strings.Replace(preReceiveScript, "{{marker}}", ManagedHookMarker, 1)
+}
+
+const preReceiveScript = `#!/bin/sh
+{{marker}}: pre-receive
+# Generated by 'dflow hooks server'. Enforces .dflow.yaml on pushes.
+
+status=0
+
+# flatten_config prints the scalar values of a .dflow.yaml document as "path.to.key=value".
+flatten_config() {
+    awk '
+    function trim(s) { sub(/^[ \t]+/, "", s); sub(/[ \t]+$/, "", s); return s }
+    BEGIN { sq = sprintf("%c", 39) }
+    /^[ \t]*(#|$)/ { next }
+    /^[ \t]*-/ { next }
+    {
+        match($0, /^ */)
+        indent = RLENGTH
+        line = substr($0, indent + 1)
+        colon = index(line, ":")
+        if (colon == 0) next
+
+        key = trim(substr(line, 1, colon - 1))
+        if (key ~ /^".*"$/ || key ~ ("^" sq ".*" sq "$")) key = substr(key, 2, length(key) - 2)
+        value = trim(substr(line, colon + 1))
+
+        while (depth > 0 && indents[depth] >= indent) depth--
+        depth++
+        indents[depth] = indent
+        keys[depth] = key
+        if (value == "") next
+
+        path = keys[1]
+        for (i = 2; i <= depth; i++) path = path "." keys[i]
+
+        if (substr(value, 1, 1) == "\"") {
+            if (match(value, /^"([^"\\]|\\.)*"/)) value = substr(value, 2, RLENGTH - 2)
+            gsub(/\\\\/, "\\", value)
+        } else if (substr(value, 1, 1) == sq) {
+            if (match(value, "^" sq "([^" sq "]|" sq sq ")*" sq)) value = substr(value, 2, RLENGTH - 2)
+            gsub(sq sq, sq, value)
+        } else {
+            sub(/[ \t]+#.*$/, "", value)
+        }
+        print path "=" value
+    }'
+}
+
+# load_config reads .dflow.yaml from the given revision, falling back to HEAD.
+load_config() {
+    config=""
+    if [ -n "$1" ]; then
+        config=$(git show "$1:.dflow.yaml" 2>/dev/null | flatten_config)
+    fi
+    if [ -z "$config" ]; then
+        config=$(git show "HEAD:.dflow.yaml" 2>/dev/null | flatten_config)
+    fi
+}
+
+# cfg prints the value of a flattened key.
+cfg() {
+    printf '%s\n' "$config" | awk -v key="$1" 'index($0, key "=") == 1 { print substr($0, length(key) + 2); exit }'
+}
+
+is_zero() {
+    case $1 in
+        *[!0]*) return 1 ;;
+        *) return 0 ;;
+    esac
+}
+
+reject() {
+    echo "dflow: $1" >&2
+    status=1
+}
+
+is_base_branch() {
+    for key in branches.main branches.develop branches.uat flow.feature_base flow.feature_merge \
+        flow.release_base flow.hotfix_base flow.bugfix_base; do
+        if [ -n "$1" ] && [ "$(cfg "$key")" = "$1" ]; then
+            return 0
+        fi
+    done
+    return 1
+}
+
+has_flow_prefix() {
+    for key in branches.features branches.releases branches.hotfixes branches.bugfixes \
+        branches.supports branches.backports; do
+        prefix=$(cfg "$key")
+        case $key in
+            branches.supports) prefix=${prefix:-support/} ;;
+            branches.backports) prefix=${prefix:-backport/} ;;
+        esac
+        if [ -n "$prefix" ]; then
+            case $1 in
+                "$prefix"*) return 0 ;;
+            esac
+        fi
+    done
+    return 1
+}
+
+check_branch() {
+    branch=$1 oldrev=$2 newrev=$3
+
+    rule=$(cfg "workflow.branch_rules.$branch")
+    if is_base_branch "$branch" || [ -n "$rule" ]; then
+        mode=${rule:-$(cfg workflow.default_merge_mode)}
+        if [ "$mode" = "manual" ] && [ "$DFLOW_ALLOW_MANUAL" != "1" ] && [ -z "$GITEA_PR_ID" ]; then
+            reject "'$branch' uses manual merges: changes must go through a pull request"
+            return
+        fi
+        if ! is_base_branch "$branch"; then
+            return
+        fi
+        if is_zero "$newrev"; then
+            reject "'$branch' is a base branch and cannot be deleted"
+        elif ! is_zero "$oldrev" && ! git merge-base --is-ancestor "$oldrev" "$newrev" 2>/dev/null; then
+            reject "'$branch' is a base branch: non-fast-forward updates are not allowed"
+        fi
+        return
+    fi
+
+    if is_zero "$newrev"; then
+        return
+    fi
+    if ! has_flow_prefix "$branch"; then
+        reject "'$branch' does not start with a configured branch prefix"
+    fi
+}
+
+# calver_pattern translates versioning.calver_format into an extended regexp.
+calver_pattern() {
+    format=$(cfg versioning.calver_format)
+    printf '%s\n' "${format:-YYYY.MM.MICRO}" | awk -F. '{
+        p["YYYY"] = "[0-9]{4}"; p["YY"] = "(0|[1-9][0-9]?)"; p["0Y"] = "[0-9]{2}"
+        p["MM"] = "(1[0-2]|[1-9])"; p["0M"] = "(0[1-9]|1[0-2])"
+        p["WW"] = "(5[0-3]|[1-4][0-9]|[1-9])"; p["0W"] = "(0[1-9]|[1-4][0-9]|5[0-3])"
+        p["DD"] = "(3[01]|[12][0-9]|[1-9])"; p["0D"] = "(0[1-9]|[12][0-9]|3[01])"
+        p["MICRO"] = "(0|[1-9][0-9]*)"
+        out = ""
+        for (i = 1; i <= NF; i++) out = out (i > 1 ? "\\." : "") p[$i]
+        print out
+    }'
+}
+
+# promotion_pattern translates promotion.tag_format into an extended regexp.
+promotion_pattern() {
+    format=$(cfg promotion.tag_format)
+    if [ -z "$format" ]; then
+        format='{to}-{date}.{n}'
+    fi
+    printf '%s\n' "$format" | sed -e 's/[][\.*^$()+?|]/\\&/g' \
+        -e 's|{from}|[0-9A-Za-z._/-]+|g' -e 's|{to}|[0-9A-Za-z._/-]+|g' \
+        -e 's|{date}|[0-9]{4}-[0-9]{2}-[0-9]{2}|g' -e 's|{n}|[0-9]+|g'
+}
+
+check_tag() {
+    tag=$1 newrev=$2
+    if is_zero "$newrev"; then
+        return
+    fi
+
+    pattern=$(cfg versioning.tag_pattern)
+    if [ -z "$pattern" ]; then
+        prefix=$(cfg versioning.tag_prefix)
+        prefix=$(printf '%s' "${prefix:-v}" | sed 's/[][\.*^$()+?{}|]/\\&/g')
+        case $(cfg versioning.scheme) in
+            free) pattern="^$prefix.+$" ;;
+            calver) pattern="^$prefix$(calver_pattern)(-[0-9A-Za-z.-]+)?$" ;;
+            *) pattern="^$prefix[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$" ;;
+        esac
+    fi
+
+    if ! printf '%s\n' "$tag" | grep -Eq -- "$pattern" &&
+        ! printf '%s\n' "$tag" | grep -Eq -- "^$(promotion_pattern)$"; then
+        reject "tag '$tag' does not match the version format '$pattern'"
+    fi
+}
+
+while read -r oldrev newrev refname; do
+    # existing branches are checked against the rules of their tip before the push
+    rev=$newrev
+    if ! is_zero "$oldrev"; then
+        case $refname in
+            refs/heads/*) rev=$oldrev ;;
+        esac
+    fi
+    if is_zero "$rev"; then
+        rev=""
+    fi
+
+    load_config "$rev"
+    if [ -z "$config" ]; then
+        continue
+    fi
+
+    case $refname in
+        refs/heads/*) check_branch "${refname#refs/heads/}" "$oldrev" "$newrev" ;;
+        refs/tags/*) check_tag "${refname#refs/tags/}" "$newrev" ;;
+    esac
+done
+
+exit $status
+`
@@ -0,0 +1,61 @@
+package gitutils
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// Tag describes a tag of the repository.
+type Tag struct {
+	Name   string // tag name, e.g. v1.3.0-rc.1
+	Date   string // creation date (tagger date, or commit date for lightweight tags), YYYY-MM-DD
+	Commit string // full hash of the tagged commit
+}
+
+// ListTags returns the tags of the repository, oldest first.
+func ListTags() []Tag {
+	format := "%(refname:short)%09%(creatordate:short)%09%(if)%(*objectname)%(then)%(*objectname)%(else)%(objectname)%(end)"
+	out, err := exec.Command("git", "for-each-ref", "--sort=creatordate", "--format="+format, "refs/tags").Output()
+	if err != nil {
+		return nil
+	}
+
+	var tags []Tag
+	for _, line := range splitLines(out) {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 {
+			continue
+		}
+		tags = append(tags, Tag{Name: fields[0], Date: fields[1], Commit: fields[2]})
+	}
+	return tags
+}
+
+// TagExists reports whether the tag exists locally.
+func TagExists(name string) bool {
+	return RefExists("refs/tags/" + name)
+}
+
+// CreateTag creates an annotated tag on ref.
+func CreateTag(name string, ref string, message string) error {
+	if out, err := exec.Command("git", "tag", "-a", name, ref, "-m", message).CombinedOutput(); err != nil {
+		return fmt.Errorf("❌ failed to create tag '%s': %s", name, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// PushTag pushes a tag to origin.
+func PushTag(name string) error {
+	spinner := utils.NewSpinner(fmt.Sprintf("Pushing tag '%s' to origin...", name))
+	spinner.Start()
+
+	if out, err := exec.Command("git", "push", "origin", "refs/tags/"+name).CombinedOutput(); err != nil {
+		spinner.Stop(fmt.Sprintf("Failed to push tag '%s'", name), "❌")
+		return fmt.Errorf("❌ failed to push tag '%s': %s", name, strings.TrimSpace(string(out)))
+	}
+	spinner.Stop(fmt.Sprintf("Pushed tag '%s' to remote\n", name), "🚀")
+	return nil
+}
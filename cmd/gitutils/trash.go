@@ -0,0 +1,242 @@
+package gitutils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// TrashRefPrefix is the ref namespace where dflow keeps the tips of deleted branches.
+const TrashRefPrefix = "refs/dflow/trash/"
+
+// trashTimeLayout formats the timestamp component of a trash ref. It only uses
+// characters that are valid in ref names. Microseconds keep backups of the same branch
+// made within a second apart; they are left out when zero, as in backups made before
+// they were recorded.
+const trashTimeLayout = "20060102-150405.999999"
+
+// TrashEntry describes a deleted branch saved under refs/dflow/trash/<branch>/<timestamp>.
+type TrashEntry struct {
+	Branch    string    // branch name at deletion time
+	Timestamp time.Time // deletion time (UTC)
+	Ref       string    // full trash ref name
+	Commit    string    // abbreviated commit the branch pointed to
+	Subject   string    // subject of that commit
+	Upstream  string    // upstream of the branch at deletion time, e.g. "origin/feature/x"
+}
+
+// ID returns the timestamp component identifying the entry among backups of the same branch.
+func (e TrashEntry) ID() string {
+	return e.Timestamp.Format(trashTimeLayout)
+}
+
+// SaveToTrash records the commit that ref points to under
+// refs/dflow/trash/<branch>/<timestamp>, together with the given upstream.
+//
+// When push is true, the backup ref is also pushed to origin so it survives
+// the loss of the local clone.
+func SaveToTrash(branch string, ref string, upstream string, push bool) (*TrashEntry, error) {
+	out, err := exec.Command("git", "rev-parse", "--verify", ref+"^{commit}").Output()
+	if err != nil {
+		return nil, fmt.Errorf("❌ failed to resolve '%s' for backup: %w", ref, err)
+	}
+	commit := strings.TrimSpace(string(out))
+
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	entry := &TrashEntry{
+		Branch:    branch,
+		Timestamp: now,
+		Ref:       TrashRefPrefix + branch + "/" + now.Format(trashTimeLayout),
+		Commit:    commit,
+		Upstream:  upstream,
+	}
+
+	// the empty old value makes git refuse to overwrite an existing backup
+	if err := exec.Command("git", "update-ref", entry.Ref, commit, "").Run(); err != nil {
+		return nil, fmt.Errorf("❌ failed to save backup ref '%s': %w", entry.Ref, err)
+	}
+
+	if upstream != "" {
+		if err := exec.Command("git", "config", trashConfigKey(entry), upstream).Run(); err != nil {
+			return nil, fmt.Errorf("❌ failed to record upstream of '%s': %w", branch, err)
+		}
+	}
+
+	if push {
+		var stderr bytes.Buffer
+		cmd := exec.Command("git", "push", "origin", entry.Ref+":"+entry.Ref)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			utils.Warn("Could not push backup ref to origin: %s", strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return entry, nil
+}
+
+// ListTrash returns the backups stored in the dflow trash, newest first.
+//
+// If branch is not empty, only the backups of that branch are returned.
+func ListTrash(branch string) ([]TrashEntry, error) {
+	out, err := exec.Command("git", "for-each-ref", "--format=%(refname)%09%(objectname:short)%09%(subject)", TrashRefPrefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dflow trash: %w", err)
+	}
+
+	var entries []TrashEntry
+	for _, line := range splitLines(out) {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) < 2 {
+			continue
+		}
+
+		name := strings.TrimPrefix(fields[0], TrashRefPrefix)
+		slash := strings.LastIndex(name, "/")
+		if slash < 0 {
+			continue
+		}
+
+		timestamp, err := time.Parse(trashTimeLayout, name[slash+1:])
+		if err != nil {
+			continue
+		}
+
+		entry := TrashEntry{
+			Branch:    name[:slash],
+			Timestamp: timestamp,
+			Ref:       fields[0],
+			Commit:    fields[1],
+		}
+		if len(fields) == 3 {
+			entry.Subject = fields[2]
+		}
+		if branch != "" && entry.Branch != branch {
+			continue
+		}
+
+		upstream, _ := exec.Command("git", "config", "--get", trashConfigKey(&entry)).Output()
+		entry.Upstream = strings.TrimSpace(string(upstream))
+
+		entries = append(entries, entry)
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Timestamp.After(entries[j].Timestamp)
+	})
+
+	return entries, nil
+}
+
+// TrashOlderThan returns the entries made more than days days before now.
+func TrashOlderThan(entries []TrashEntry, days int, now time.Time) []TrashEntry {
+	cutoff := now.UTC().AddDate(0, 0, -days)
+	var old []TrashEntry
+	for _, entry := range entries {
+		if entry.Timestamp.Before(cutoff) {
+			old = append(old, entry)
+		}
+	}
+	return old
+}
+
+// RemoveFromTrash deletes a backup ref and its recorded upstream.
+//
+// When remote is true, the backup ref is also deleted from origin.
+func RemoveFromTrash(entry TrashEntry, remote bool) error {
+	if err := exec.Command("git", "update-ref", "-d", entry.Ref).Run(); err != nil {
+		return fmt.Errorf("❌ failed to remove backup ref '%s': %w", entry.Ref, err)
+	}
+
+	_ = exec.Command("git", "config", "--remove-section", "dflow-trash."+entry.Branch+"/"+entry.ID()).Run()
+
+	if remote {
+		var stderr bytes.Buffer
+		cmd := exec.Command("git", "push", "origin", "--delete", entry.Ref)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			utils.Warn("Could not delete backup ref from origin: %s", strings.TrimSpace(stderr.String()))
+		}
+	}
+
+	return nil
+}
+
+// RestoreFromTrash recreates a local branch named branch at the commit saved in entry.
+//
+// If the entry recorded an upstream, the upstream is restored as well: the branch is
+// pushed again when it no longer exists on the remote, otherwise tracking is set up.
+func RestoreFromTrash(entry TrashEntry, branch string) error {
+	if LocalBranchExists(branch) {
+		return fmt.Errorf("❌ branch '%s' already exists locally", branch)
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "branch", branch, entry.Ref)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to recreate branch '%s': %s", branch, strings.TrimSpace(stderr.String()))
+	}
+	utils.Success("Recreated branch '%s' at %s", branch, entry.Commit)
+
+	if entry.Upstream == "" {
+		return nil
+	}
+
+	remote, remoteBranch, found := strings.Cut(entry.Upstream, "/")
+	if !found {
+		return nil
+	}
+
+	exists := exec.Command("git", "ls-remote", "--exit-code", "--heads", remote, remoteBranch).Run() == nil
+	if exists {
+		if err := exec.Command("git", "fetch", "--quiet", remote, remoteBranch).Run(); err != nil {
+			return fmt.Errorf("❌ failed to fetch '%s': %w", entry.Upstream, err)
+		}
+		if err := exec.Command("git", "branch", "--set-upstream-to="+entry.Upstream, branch).Run(); err != nil {
+			return fmt.Errorf("❌ failed to set upstream of '%s' to '%s': %w", branch, entry.Upstream, err)
+		}
+		utils.Success("Tracking '%s'", entry.Upstream)
+		return nil
+	}
+
+	spinner := utils.NewSpinner(fmt.Sprintf("Pushing branch '%s' to %s...", branch, remote))
+	spinner.Start()
+
+	stderr.Reset()
+	cmd = exec.Command("git", "push", "-u", remote, branch+":"+remoteBranch)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		spinner.Stop("Failed to restore remote branch.", "❌")
+		return fmt.Errorf("❌ failed to push '%s' to '%s': %s", branch, entry.Upstream, strings.TrimSpace(stderr.String()))
+	}
+	spinner.Stop(fmt.Sprintf("Restored remote branch '%s'", entry.Upstream), "🚀")
+
+	return nil
+}
+
+// Upstream returns the upstream configured for a local branch (e.g. "origin/feature/x"),
+// or an empty string if it has none.
+func Upstream(branch string) string {
+	out, err := exec.Command("git", "rev-parse", "--abbrev-ref", "--symbolic-full-name", branch+"@{upstream}").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// FetchRemoteBranch updates refs/remotes/origin/<branch> from origin, also when the
+// branch was force-pushed there.
+func FetchRemoteBranch(branch string) error {
+	refspec := fmt.Sprintf("+refs/heads/%s:refs/remotes/origin/%s", branch, branch)
+	return exec.Command("git", "fetch", "--quiet", "origin", refspec).Run()
+}
+
+// trashConfigKey returns the git config key holding the upstream of a trash entry.
+func trashConfigKey(entry *TrashEntry) string {
+	return "dflow-trash." + entry.Branch + "/" + entry.ID() + ".upstream"
+}
@@ -0,0 +1,116 @@
+package gitutils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// Outcomes of FastForward.
+const (
+	FFUpdated  = "updated"    // the local branch was moved to origin's tip
+	FFCreated  = "created"    // the branch only existed on origin and was created locally
+	FFUpToDate = "up to date" // the local branch already matches origin
+	FFAhead    = "ahead"      // the local branch has commits origin does not have
+	FFDiverged = "diverged"   // both sides have commits; a fast-forward is impossible
+	FFNoRemote = "no remote"  // the branch does not exist on origin
+	FFSkipped  = "skipped"    // the branch could not be updated safely (see Detail)
+	FFFailed   = "failed"     // Git refused the update (see Detail)
+)
+
+// FFResult reports what FastForward did with a branch.
+type FFResult struct {
+	Branch string
+	Status string
+	Detail string
+}
+
+// Fetch downloads all branches and tags from origin, pruning deleted remote branches.
+func Fetch() error {
+	spinner := utils.NewSpinner("Fetching from origin...")
+	spinner.Start()
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "fetch", "--prune", "--tags", "origin")
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		spinner.Stop("Failed to fetch from origin.", "❌")
+		return fmt.Errorf("❌ failed to fetch from origin: %s", strings.TrimSpace(stderr.String()))
+	}
+
+	spinner.Stop("Fetched latest changes from origin.")
+	return nil
+}
+
+// FastForward moves the local branch to origin/<branch> if that is a fast-forward,
+// without switching the current checkout.
+//
+// Branches that are not checked out are moved with `git update-ref`. The branch checked
+// out in the current worktree is updated with `git merge --ff-only`, and branches checked
+// out in other worktrees are skipped, since moving them would desynchronize those trees.
+// FastForward uses the remote-tracking refs as they are; call Fetch first.
+func FastForward(branch string) FFResult {
+	result := FFResult{Branch: branch}
+	remote := "refs/remotes/origin/" + branch
+
+	if !RefExists(remote) {
+		result.Status = FFNoRemote
+		return result
+	}
+
+	if !LocalBranchExists(branch) {
+		if err := exec.Command("git", "branch", "--track", branch, "origin/"+branch).Run(); err != nil {
+			result.Status, result.Detail = FFFailed, err.Error()
+			return result
+		}
+		result.Status = FFCreated
+		return result
+	}
+
+	local, remoteTip := HeadCommit("refs/heads/"+branch), HeadCommit(remote)
+	switch {
+	case local == remoteTip:
+		result.Status = FFUpToDate
+		return result
+	case isAncestor(remoteTip, local):
+		result.Status = FFAhead
+		result.Detail = fmt.Sprintf("%d commit(s) not on origin", CommitCount(remote, "refs/heads/"+branch))
+		return result
+	case !isAncestor(local, remoteTip):
+		result.Status = FFDiverged
+		result.Detail = fmt.Sprintf("%d local and %d remote commit(s) differ",
+			CommitCount(remote, "refs/heads/"+branch), CommitCount("refs/heads/"+branch, remote))
+		return result
+	}
+
+	var stderr bytes.Buffer
+	var cmd *exec.Cmd
+	if branch == CurrentBranch() {
+		cmd = exec.Command("git", "merge", "--ff-only", "--quiet", remote)
+	} else {
+		root, _ := RepoRoot()
+		if path, ok := Worktrees()[branch]; ok && path != root {
+			result.Status, result.Detail = FFSkipped, "checked out in "+path
+			return result
+		}
+		cmd = exec.Command("git", "update-ref", "-m", "dflow update: fast-forward", "refs/heads/"+branch, remoteTip, local)
+	}
+
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		result.Status, result.Detail = FFFailed, strings.TrimSpace(stderr.String())
+		return result
+	}
+
+	result.Status = FFUpdated
+	result.Detail = fmt.Sprintf("%.7s..%.7s", local, remoteTip)
+	return result
+}
+
+// isAncestor reports whether commit a is an ancestor of (or equal to) commit b.
+func isAncestor(a string, b string) bool {
+	return exec.Command("git", "merge-base", "--is-ancestor", a, b).Run() == nil
+}
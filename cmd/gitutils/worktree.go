@@ -0,0 +1,83 @@
+package gitutils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RepoRoot returns the absolute path of the top-level directory of the current working tree.
+func RepoRoot() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate repository root: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// GitCommonDir returns the absolute path of the Git directory shared by all worktrees
+// (the `.git` directory of the main working tree).
+func GitCommonDir() (string, error) {
+	out, err := exec.Command("git", "rev-parse", "--path-format=absolute", "--git-common-dir").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate git directory: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// AddWorktree creates a new branch at startPoint and checks it out in a new worktree at path.
+//
+// It wraps `git worktree add --no-track -b <branch> <path> <startPoint>`, leaving the
+// current checkout untouched.
+func AddWorktree(path string, branch string, startPoint string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", "worktree", "add", "--no-track", "-b", branch, path, startPoint)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to create worktree for '%s': %s", branch, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// RemoveWorktree removes the worktree at path.
+//
+// Unless force is true, Git refuses to remove worktrees with uncommitted changes.
+func RemoveWorktree(path string, force bool) error {
+	args := []string{"worktree", "remove", path}
+	if force {
+		args = append(args, "--force")
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.Command("git", args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("❌ failed to remove worktree '%s': %s", path, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// Worktrees returns the branches checked out in any worktree of the repository,
+// mapped to the worktree path. The main working tree is included.
+func Worktrees() map[string]string {
+	worktrees := make(map[string]string)
+
+	out, err := exec.Command("git", "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return worktrees
+	}
+
+	var path string
+	for _, line := range strings.Split(string(out), "\n") {
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "branch "):
+			branch := strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")
+			worktrees[branch] = path
+		}
+	}
+
+	return worktrees
+}
@@ -0,0 +1,127 @@
+// Package lifecycle runs the hooks teams attach to dflow operations, such as creating a
+// database schema after a feature starts or notifying QA after a release is finished.
+//
+// A hook is found in two places, and both run when present:
+//
+//   - Commands declared under `hooks.<name>` in .dflow.yaml, run through the shell
+//   - An executable file named after the hook in `.dflow/hooks/` (e.g. `.dflow/hooks/pre-start`)
+//
+// Hooks run from the repository root. They receive the operation context as DFLOW_*
+// environment variables and as a JSON document on stdin. A pre-hook that exits with a
+// non-zero status aborts the operation; post-hooks can only report failures.
+package lifecycle
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// HooksDir is the directory, relative to the repository root, holding executable hooks.
+const HooksDir = ".dflow/hooks"
+
+// Context describes the operation a hook runs for. It is passed to hooks as environment
+// variables (DFLOW_HOOK, DFLOW_BRANCH_TYPE, DFLOW_BRANCH, DFLOW_BASE, DFLOW_TARGET,
+// DFLOW_REPO_ROOT) and as JSON on stdin.
+type Context struct {
+	Hook     string `json:"hook"`
+	Type     string `json:"type"`
+	Branch   string `json:"branch"`
+	Base     string `json:"base"`
+	Target   string `json:"target"`
+	RepoRoot string `json:"repo_root"`
+}
+
+// Run executes the hook named hook with the given context: first the commands declared
+// in the configuration, then the executable in HooksDir. It stops at the first failure
+// and returns an error describing it.
+func Run(cfg *utils.Config, hook string, ctx Context) error {
+	root, err := gitutils.RepoRoot()
+	if err != nil {
+		return err
+	}
+	ctx.Hook, ctx.RepoRoot = hook, root
+
+	var commands []*exec.Cmd
+	for _, command := range cfg.Hooks[hook] {
+		commands = append(commands, shellCommand(command))
+	}
+
+	script := filepath.Join(root, HooksDir, hook)
+	if info, err := os.Stat(script); err == nil && !info.IsDir() {
+		if runtime.GOOS != "windows" && info.Mode()&0111 == 0 {
+			utils.Warn("Ignoring %s: it is not executable (chmod +x to enable it)", filepath.Join(HooksDir, hook))
+		} else {
+			commands = append(commands, exec.Command(script))
+		}
+	}
+
+	if len(commands) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(ctx)
+	if err != nil {
+		return err
+	}
+
+	utils.Info("Running %s hook...", hook)
+	for _, cmd := range commands {
+		cmd.Dir = root
+		cmd.Env = append(os.Environ(), environment(ctx)...)
+		cmd.Stdin = bytes.NewReader(payload)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s hook '%s' failed: %w", hook, strings.Join(cmd.Args, " "), err)
+		}
+	}
+
+	return nil
+}
+
+// RunPre runs a pre-hook and reports whether the operation may continue.
+func RunPre(cfg *utils.Config, hook string, ctx Context) bool {
+	if err := Run(cfg, hook, ctx); err != nil {
+		utils.Error(err.Error())
+		utils.Info("The operation was aborted by the %s hook.", hook)
+		return false
+	}
+	return true
+}
+
+// RunPost runs a post-hook, only warning about failures since the operation already happened.
+func RunPost(cfg *utils.Config, hook string, ctx Context) {
+	if err := Run(cfg, hook, ctx); err != nil {
+		utils.Warn(err.Error())
+	}
+}
+
+// environment returns the DFLOW_* variables describing ctx.
+func environment(ctx Context) []string {
+	return []string{
+		"DFLOW_HOOK=" + ctx.Hook,
+		"DFLOW_BRANCH_TYPE=" + ctx.Type,
+		"DFLOW_BRANCH=" + ctx.Branch,
+		"DFLOW_BASE=" + ctx.Base,
+		"DFLOW_TARGET=" + ctx.Target,
+		"DFLOW_REPO_ROOT=" + ctx.RepoRoot,
+	}
+}
+
+// shellCommand runs command through the platform shell.
+func shellCommand(command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", command)
+	}
+	return exec.Command("sh", "-c", command)
+}
@@ -0,0 +1,207 @@
+// Package plugins discovers and runs external dflow subcommands.
+//
+// Like git and kubectl plugins, any executable named `dflow-<name>` becomes available as
+// `dflow <name>`. Plugins are looked up in the repository's `.dflow/plugins/` directory
+// first and then on PATH. An optional `dflow_complete-<name>` executable on PATH provides
+// the shell completions of a plugin. Completion helpers run on TAB without the user typing
+// a plugin command, so those of `.dflow/plugins/` are only used once the user opts in with
+// `git config dflow.repoCompletions true`.
+//
+// Plugins receive the dflow context through environment variables:
+//
+//   - DFLOW_CONFIG: path of the .dflow.yaml file, when there is one
+//   - DFLOW_CONFIG_JSON: the resolved configuration as JSON, using the YAML keys
+//   - DFLOW_REPO_ROOT: top-level directory of the current repository
+//   - DFLOW_VERSION: version of the dflow binary running the plugin
+//   - DFLOW_PLUGIN_NAME: name the plugin was invoked as
+package plugins
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Prefix is the file name prefix of plugin executables.
+const Prefix = "dflow-"
+
+// CompletionPrefix is the file name prefix of plugin completion helpers.
+const CompletionPrefix = "dflow_complete-"
+
+// Dir is the directory, relative to the repository root, holding repository plugins.
+const Dir = ".dflow/plugins"
+
+// RepoCompletionsKey is the git config key enabling the completion helpers of Dir.
+const RepoCompletionsKey = "dflow.repoCompletions"
+
+// Plugin is an external executable exposed as a dflow subcommand.
+type Plugin struct {
+	Name       string // subcommand name, e.g. "deploy-preview"
+	Path       string // absolute path of the dflow-<name> executable
+	Completion string // path of the dflow_complete-<name> executable, if any
+}
+
+// Discover returns the plugins found in the repository plugin directory and on PATH,
+// sorted by name. When the same name appears more than once, the first one found wins,
+// so repository plugins take precedence over PATH. Completion helpers are looked up the
+// same way, skipping the repository directory unless RepoCompletionsKey is set.
+func Discover() []Plugin {
+	repoDir := ""
+	var dirs []string
+	if root, err := gitutils.RepoRoot(); err == nil {
+		repoDir = filepath.Join(root, Dir)
+		dirs = append(dirs, repoDir)
+	}
+	dirs = append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+	repoCompletions := repoDir != "" && repoCompletionsEnabled()
+
+	// every directory is listed once, collecting plugins and completion helpers together
+	seen := make(map[string]bool)
+	completions := make(map[string]string)
+	var plugins []Plugin
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+			if name, ok := pluginName(entry.Name(), Prefix); ok {
+				if !seen[name] && isExecutable(path) {
+					seen[name] = true
+					plugins = append(plugins, Plugin{Name: name, Path: path})
+				}
+				continue
+			}
+			if name, ok := pluginName(entry.Name(), CompletionPrefix); ok && completions[name] == "" {
+				if (dir != repoDir || repoCompletions) && isExecutable(path) {
+					completions[name] = path
+				}
+			}
+		}
+	}
+
+	for i := range plugins {
+		plugins[i].Completion = completions[plugins[i].Name]
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+// Command returns the command running the plugin with args, wired to the terminal
+// and with the dflow environment set.
+func (p Plugin) Command(args []string) *exec.Cmd {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), Environment(p.Name)...)
+	return cmd
+}
+
+// Complete asks the plugin's completion helper for the completions of args, where the
+// last element is the word being completed. It returns nil when the plugin has no helper.
+func (p Plugin) Complete(args []string) []string {
+	if p.Completion == "" {
+		return nil
+	}
+
+	cmd := exec.Command(p.Completion, args...)
+	cmd.Env = append(os.Environ(), Environment(p.Name)...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+
+	var completions []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			completions = append(completions, line)
+		}
+	}
+	return completions
+}
+
+// Environment returns the DFLOW_* variables passed to the plugin named name.
+func Environment(name string) []string {
+	env := []string{
+		"DFLOW_VERSION=" + utils.Version(),
+		"DFLOW_PLUGIN_NAME=" + name,
+	}
+
+	if root, err := gitutils.RepoRoot(); err == nil {
+		env = append(env, "DFLOW_REPO_ROOT="+root)
+	}
+
+	path := utils.ConfigPath()
+	if _, err := os.Stat(path); err != nil {
+		return env
+	}
+	env = append(env, "DFLOW_CONFIG="+path)
+
+	if cfg, err := utils.LoadConfig(); err == nil {
+		if data, err := configJSON(cfg); err == nil {
+			env = append(env, "DFLOW_CONFIG_JSON="+string(data))
+		}
+	}
+	return env
+}
+
+// configJSON encodes cfg as JSON using the same keys as .dflow.yaml.
+func configJSON(cfg *utils.Config) ([]byte, error) {
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	var generic map[string]interface{}
+	if err := yaml.Unmarshal(data, &generic); err != nil {
+		return nil, err
+	}
+	return json.Marshal(generic)
+}
+
+// repoCompletionsEnabled reports whether the user allowed the completion helpers of the
+// repository plugin directory. Only git config is trusted for it: .dflow.yaml comes with
+// the repository.
+func repoCompletionsEnabled() bool {
+	out, err := exec.Command("git", "config", "--bool", "--get", RepoCompletionsKey).Output()
+	return err == nil && strings.TrimSpace(string(out)) == "true"
+}
+
+// pluginName extracts the plugin name from a file name with the given prefix,
+// dropping the executable extension on Windows.
+func pluginName(file string, prefix string) (string, bool) {
+	if !strings.HasPrefix(file, prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(file, prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name, name != ""
+}
+
+// isExecutable reports whether path is a regular file that can be executed.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode()&0111 != 0
+}
@@ -0,0 +1,74 @@
+// Package root defines the root command for the dflow CLI.
+//
+// This package initializes the top-level `dflow` command, sets up persistent behavior
+// (like displaying the banner), and attaches all subcommands such as `init`, `start`,
+// and `config`. It uses Cobra for command parsing.
+package root
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/plugins"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// registerPlugins adds a subcommand for every `dflow-<name>` plugin found in the
+// repository's .dflow/plugins directory or on PATH. Plugins cannot replace built-in
+// commands.
+func registerPlugins() {
+	for _, plugin := range plugins.Discover() {
+		if isBuiltinCommand(plugin.Name) {
+			continue
+		}
+		RootCmd.AddCommand(newPluginCommand(plugin))
+	}
+}
+
+// newPluginCommand returns a command forwarding its arguments, including flags such as
+// --help, to the plugin executable and exiting with the plugin's status. The output
+// belongs to the plugin, so the banner is not printed.
+func newPluginCommand(plugin plugins.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:                plugin.Name,
+		Short:              fmt.Sprintf("Plugin %s%s (run 'dflow %s --help' for details)", plugins.Prefix, plugin.Name, plugin.Name),
+		Long:               fmt.Sprintf("External plugin provided by %s.", plugin.Path),
+		Annotations:        map[string]string{utils.AnnotationNoBanner: "plugin"},
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			err := plugin.Command(args).Run()
+
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				os.Exit(exitErr.ExitCode())
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "❌ failed to run plugin '%s': %v\n", plugin.Name, err)
+				os.Exit(1)
+			}
+			return nil
+		},
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if plugin.Completion == "" {
+				return nil, cobra.ShellCompDirectiveDefault
+			}
+			return plugin.Complete(append(args, toComplete)), cobra.ShellCompDirectiveNoFileComp
+		},
+	}
+}
+
+// isBuiltinCommand reports whether name is already taken by a dflow command or alias.
+func isBuiltinCommand(name string) bool {
+	if name == "help" {
+		return true
+	}
+	for _, cmd := range RootCmd.Commands() {
+		if cmd.Name() == name || cmd.HasAlias(name) {
+			return true
+		}
+	}
+	return false
+}
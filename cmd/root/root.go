@@ -28,6 +28,9 @@ var RootCmd = &cobra.Command{
 		if len(os.Args) > 1 && (strings.HasPrefix(os.Args[1], "__complete") || os.Args[1] == "completion") {
 			return
 		}
+		if _, ok := cmd.Annotations[utils.AnnotationNoBanner]; ok {
+			return
+		}
 		utils.PrintBanner()
 	},
 
@@ -43,7 +46,10 @@ var RootCmd = &cobra.Command{
 // Execute runs the root command for the dflow CLI.
 //
 // It should be called from the `main` function in main.go to start the CLI.
+// External `dflow-<name>` plugins are discovered and registered first.
 func Execute() {
+	registerPlugins()
+
 	if err := RootCmd.Execute(); err != nil {
 		// fmt.Println(err)
 		os.Exit(1)
@@ -54,8 +60,21 @@ func init() {
 	RootCmd.AddCommand(CompletionCmd)
 	RootCmd.AddCommand(commands.InitCmd)
 	RootCmd.AddCommand(commands.StartCmd)
+	RootCmd.AddCommand(commands.FinishCmd)
 	RootCmd.AddCommand(commands.ConfigCmd)
 	RootCmd.AddCommand(commands.DeleteCmd)
+	RootCmd.AddCommand(commands.StatusCmd)
+	RootCmd.AddCommand(commands.ListCmd)
+	RootCmd.AddCommand(commands.SyncCmd)
+	RootCmd.AddCommand(commands.UpdateCmd)
+	RootCmd.AddCommand(commands.RestoreCmd)
+	RootCmd.AddCommand(commands.TrashCmd)
+	RootCmd.AddCommand(commands.HooksCmd)
+	RootCmd.AddCommand(commands.CiCmd)
+	RootCmd.AddCommand(commands.VersionCmd)
+	RootCmd.AddCommand(commands.ReleaseCmd)
+	RootCmd.AddCommand(commands.BackportCmd)
+	RootCmd.AddCommand(commands.PromoteCmd)
 
 	// customize help
 	RootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
@@ -0,0 +1,90 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+)
+
+// TestBranchCommits checks that the commits of a flow branch leave out the commits it got
+// from syncing with its base, before and after the branch is merged into it.
+func TestBranchCommits(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", "-b", "develop", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commit := func(subject string) {
+		git("commit", "-q", "--allow-empty", "-m", subject)
+	}
+	subjects := func(commits []gitutils.Commit) []string {
+		var out []string
+		for _, c := range commits {
+			out = append(out, c.Subject)
+		}
+		return out
+	}
+
+	commit("init")
+	start := git("rev-parse", "HEAD")
+
+	// merge sync: develop is merged into the branch halfway
+	git("checkout", "-q", "-b", "bugfix/merged")
+	commit("fix: one")
+	git("checkout", "-q", "develop")
+	commit("feat: develop work")
+	git("checkout", "-q", "bugfix/merged")
+	git("merge", "-q", "--no-edit", "develop")
+	commit("fix: two")
+
+	want := []string{"fix: one", "fix: two"}
+	if got := subjects(gitutils.BranchCommits("bugfix/merged", "develop", start)); !reflect.DeepEqual(got, want) {
+		t.Errorf("after a merge sync: got %v, want %v", got, want)
+	}
+
+	git("checkout", "-q", "develop")
+	git("merge", "-q", "--no-ff", "-m", "Merge bugfix", "bugfix/merged")
+	if got := subjects(gitutils.BranchCommits("bugfix/merged", "develop", start)); !reflect.DeepEqual(got, want) {
+		t.Errorf("once merged into develop: got %v, want %v", got, want)
+	}
+
+	// rebase sync: the branch is replayed on top of newer develop work
+	rebaseStart := git("rev-parse", "HEAD")
+	git("checkout", "-q", "-b", "bugfix/rebased")
+	commit("fix: three")
+	git("checkout", "-q", "develop")
+	commit("feat: more develop work")
+	git("checkout", "-q", "bugfix/rebased")
+	git("rebase", "-q", "develop")
+
+	want = []string{"fix: three"}
+	if got := subjects(gitutils.BranchCommits("bugfix/rebased", "develop", rebaseStart)); !reflect.DeepEqual(got, want) {
+		t.Errorf("after a rebase sync: got %v, want %v", got, want)
+	}
+
+	// fast-forwarded into develop: its commits since the start are used
+	git("checkout", "-q", "develop")
+	git("merge", "-q", "--ff-only", "bugfix/rebased")
+	if got := subjects(gitutils.BranchCommits("bugfix/rebased", "develop", git("rev-parse", "bugfix/rebased~1"))); !reflect.DeepEqual(got, want) {
+		t.Errorf("once fast-forwarded into develop: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// TestValidatePullRequest checks pull request directions against the configured flow.
+func TestValidatePullRequest(t *testing.T) {
+	cfg := &utils.Config{}
+	cfg.Branches.Main = "main"
+	cfg.Branches.Develop = "develop"
+	cfg.Branches.Uat = "uat"
+	cfg.Branches.Features = "feature/"
+	cfg.Branches.Releases = "release/"
+	cfg.Branches.Hotfixes = "hotfix/"
+	cfg.Branches.Bugfixes = "bugfix/"
+	cfg.Flow.FeatureBase = "develop"
+	cfg.Flow.FeatureMerge = "uat"
+	cfg.Flow.HotfixBase = "main"
+	cfg.Flow.BugfixBase = "develop"
+
+	tests := []struct {
+		source string
+		target string
+		valid  bool
+	}{
+		{"feature/login", "uat", true},
+		{"feature/login", "main", false},
+		{"feature/login", "develop", false},
+		{"bugfix/crash", "develop", true},
+		{"hotfix/urgent", "main", true},
+		{"hotfix/urgent", "develop", false},
+		{"release/1.2.0", "main", true},
+		{"release/1.2.0", "develop", false},
+		{"uat", "main", true},
+		{"main", "feature/login", false},
+		{"develop", "develop", false},
+		{"wip/experiment", "develop", false},
+		{"hotfix/1.4.3", "support/1.x", true},
+		{"support/1.x", "main", false},
+		{"backport/release/2.3.0/fix-login", "release/2.3.0", true},
+		{"backport/release/2.3.0/fix-login", "main", false},
+	}
+
+	for _, tt := range tests {
+		err := validators.ValidatePullRequest(cfg, tt.source, tt.target)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidatePullRequest(%q, %q) error = %v, want valid=%v", tt.source, tt.target, err, tt.valid)
+		}
+	}
+}
+
+// TestSupportLine checks which versions belong to the line of a support branch.
+func TestSupportLine(t *testing.T) {
+	cfg := &utils.Config{}
+
+	tests := []struct {
+		branch  string
+		version string
+		want    bool
+	}{
+		{"support/1.x", "1.4.3", true},
+		{"support/1.x", "v1.4.3", true},
+		{"support/1.x", "2.0.1", false},
+		{"support/1.4.x", "1.4.3", true},
+		{"support/1.4.x", "1.5.0", false},
+		{"support/legacy", "3.0.0", true},
+	}
+	for _, tt := range tests {
+		if got := utils.InSupportLine(cfg, tt.branch, tt.version); got != tt.want {
+			t.Errorf("InSupportLine(%q, %q) = %v, want %v", tt.branch, tt.version, got, tt.want)
+		}
+	}
+
+	if utils.HasVersionLine(cfg, "support/legacy") || !utils.HasVersionLine(cfg, "support/1.x") {
+		t.Error("only support branches with wildcards are version lines")
+	}
+	if got := utils.BackportTarget(cfg, "backport/support/1.x/fix-login"); got != "support/1.x" {
+		t.Errorf("BackportTarget = %q, want support/1.x", got)
+	}
+}
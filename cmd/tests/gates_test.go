@@ -0,0 +1,71 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gates"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// TestRunGates checks that gates run from their directory with their environment,
+// that failures and timeouts are reported, and that results become commit trailers.
+func TestRunGates(t *testing.T) {
+	root := t.TempDir()
+
+	results := gates.Run([]utils.Gate{
+		{Name: "env", Run: `test "$GATE_VALUE" = ok`, Env: map[string]string{"GATE_VALUE": "ok"}},
+		{Name: "fails", Run: "exit 3"},
+		{Name: "slow", Run: "exec sleep 5", Timeout: "200ms"},
+	}, root)
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+	if !results[0].Passed {
+		t.Errorf("expected the env gate to pass: %v", results[0].Err)
+	}
+	if results[1].Passed || results[1].TimedOut {
+		t.Errorf("expected the failing gate to fail without timing out")
+	}
+	if !results[2].TimedOut {
+		t.Errorf("expected the slow gate to time out")
+	}
+	if gates.Passed(results) {
+		t.Error("expected Passed to be false when a gate failed")
+	}
+
+	if trailer := results[0].Trailer(); !strings.HasPrefix(trailer, "Dflow-Gate: env passed") {
+		t.Errorf("unexpected trailer '%s'", trailer)
+	}
+
+	message := gates.AppendTrailers("Merge feature 'feature/x' into develop", []string{results[0].Trailer()})
+	if !strings.Contains(message, "into develop\n\nDflow-Gate: env passed") {
+		t.Errorf("trailers not separated by a blank line: %q", message)
+	}
+}
+
+// TestGateTimeoutKillsChildren checks that a timed out gate stops the processes its shell
+// started, not only the shell.
+func TestGateTimeoutKillsChildren(t *testing.T) {
+	root := t.TempDir()
+
+	start := time.Now()
+	results := gates.Run([]utils.Gate{
+		{Name: "forks", Run: `sh -c 'sleep 1; touch marker'; true`, Timeout: "200ms"},
+	}, root)
+	if !results[0].TimedOut {
+		t.Fatalf("expected the gate to time out")
+	}
+	if elapsed := time.Since(start); elapsed > 900*time.Millisecond {
+		t.Errorf("the gate returned after %s, long after its timeout", elapsed)
+	}
+
+	time.Sleep(1500 * time.Millisecond)
+	if _, err := os.Stat(filepath.Join(root, "marker")); err == nil {
+		t.Error("a process started by the gate kept running after the timeout")
+	}
+}
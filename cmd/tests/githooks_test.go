@@ -0,0 +1,100 @@
+package tests
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// TestValidateCommitMessage checks the default Conventional Commits pattern and the
+// messages Git generates itself.
+func TestValidateCommitMessage(t *testing.T) {
+	tests := []struct {
+		message string
+		valid   bool
+	}{
+		{"feat: add login form", true},
+		{"fix(api)!: drop legacy endpoint\n\nBREAKING CHANGE: removed", true},
+		{"# comment from git\nchore(deps): bump yaml", true},
+		{"Merge branch 'develop' into feature/x", true},
+		{"fixup! feat: add login form", true},
+		{"added login form", false},
+		{"feat:missing space", false},
+		{"\n# only comments\n", false},
+	}
+
+	for _, tt := range tests {
+		err := validators.ValidateCommitMessage(utils.DefaultCommitPattern, tt.message)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateCommitMessage(%q) error = %v, want valid=%v", tt.message, err, tt.valid)
+		}
+	}
+}
+
+// TestValidatePush checks that manual-mode branches and branches outside the configured
+// prefixes are rejected.
+func TestValidatePush(t *testing.T) {
+	cfg := &utils.Config{}
+	cfg.Branches.Main = "main"
+	cfg.Branches.Develop = "develop"
+	cfg.Branches.Features = "feature/"
+	cfg.Branches.Hotfixes = "hotfix/"
+	cfg.Workflow.DefaultMergeMode = "auto"
+	cfg.Workflow.BranchRules = map[string]string{"main": "manual"}
+
+	tests := []struct {
+		branch string
+		valid  bool
+	}{
+		{"main", false},
+		{"develop", true},
+		{"feature/login", true},
+		{"hotfix/urgent", true},
+		{"wip/experiment", false},
+	}
+
+	for _, tt := range tests {
+		err := validators.ValidatePush(cfg, tt.branch)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidatePush(%q) error = %v, want valid=%v", tt.branch, err, tt.valid)
+		}
+	}
+}
+
+// TestInstallHookChainsExistingHooks checks that installing keeps existing hooks as
+// chained hooks and that uninstalling restores them.
+func TestInstallHookChainsExistingHooks(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "pre-push")
+	if err := os.WriteFile(existing, []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	chained, err := gitutils.InstallHook(dir, "pre-push")
+	if err != nil || !chained {
+		t.Fatalf("InstallHook() chained = %v, err = %v", chained, err)
+	}
+	if !gitutils.IsManagedHook(existing) {
+		t.Error("expected the installed hook to be managed by dflow")
+	}
+
+	// installing again must not chain the managed hook onto itself
+	if chained, err := gitutils.InstallHook(dir, "pre-push"); err != nil || chained {
+		t.Errorf("reinstall chained = %v, err = %v", chained, err)
+	}
+
+	removed, restored, err := gitutils.UninstallHook(dir, "pre-push")
+	if err != nil || !removed || !restored {
+		t.Fatalf("UninstallHook() removed = %v, restored = %v, err = %v", removed, restored, err)
+	}
+	if gitutils.IsManagedHook(existing) {
+		t.Error("expected the original hook to be restored")
+	}
+	if _, err := os.Stat(existing + gitutils.ChainedHookSuffix); !os.IsNotExist(err) {
+		t.Error("expected the chained copy to be gone")
+	}
+}
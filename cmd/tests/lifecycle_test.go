@@ -0,0 +1,67 @@
+package tests
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/lifecycle"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// TestLifecycleHooks checks that declared and executable hooks receive the operation
+// context through the environment and stdin, and that failures are reported.
+func TestLifecycleHooks(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	hooksDir := filepath.Join(repo, lifecycle.HooksDir)
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	script := "#!/bin/sh\ncat > \"$DFLOW_REPO_ROOT/payload.json\"\n"
+	if err := os.WriteFile(filepath.Join(hooksDir, utils.HookPostStart), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &utils.Config{Hooks: map[string][]string{
+		utils.HookPostStart: {`echo "$DFLOW_BRANCH_TYPE" > env.txt`},
+		utils.HookPreStart:  {`test "$DFLOW_TARGET" = main`},
+	}}
+	ctx := lifecycle.Context{Type: utils.TypeFeature, Branch: "feature/x", Base: "develop", Target: "develop"}
+
+	if err := lifecycle.Run(cfg, utils.HookPreStart, ctx); err == nil {
+		t.Error("expected the pre-start hook to fail")
+	}
+
+	if err := lifecycle.Run(cfg, utils.HookPostStart, ctx); err != nil {
+		t.Fatalf("post-start hook failed: %v", err)
+	}
+
+	env, _ := os.ReadFile(filepath.Join(repo, "env.txt"))
+	if string(env) != "feature\n" {
+		t.Errorf("expected DFLOW_BRANCH_TYPE=feature, got %q", env)
+	}
+
+	data, err := os.ReadFile(filepath.Join(repo, "payload.json"))
+	if err != nil {
+		t.Fatalf("hook script did not run: %v", err)
+	}
+	var payload lifecycle.Context
+	if err := json.Unmarshal(data, &payload); err != nil {
+		t.Fatalf("invalid JSON on stdin: %v", err)
+	}
+	if payload.Hook != utils.HookPostStart || payload.Branch != "feature/x" || payload.Base != "develop" {
+		t.Errorf("unexpected payload %+v", payload)
+	}
+}
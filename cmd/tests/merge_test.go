@@ -0,0 +1,126 @@
+package tests
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// TestMergeStrategyConfig checks that merge strategies can be configured per type, either
+// for every target or per target, and that unknown strategies are rejected.
+func TestMergeStrategyConfig(t *testing.T) {
+	tmpDir := t.TempDir()
+	os.Setenv("DFLOW_CWD", tmpDir)
+	defer os.Unsetenv("DFLOW_CWD")
+
+	config := `
+workflow:
+    merge_strategy:
+        feature: squash
+        release:
+            main: no-ff
+            "*": ff-only
+    merge_message:
+        feature: "{ticket}: {branch} ({author})"
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, ".dflow.yaml"), []byte(config), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := utils.LoadConfig()
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	tests := []struct {
+		branchType, target, want string
+	}{
+		{utils.TypeFeature, "develop", utils.MergeSquash},
+		{utils.TypeRelease, "main", utils.MergeNoFF},
+		{utils.TypeRelease, "develop", utils.MergeFFOnly},
+		{utils.TypeHotfix, "main", utils.DefaultMergeStrategy},
+	}
+	for _, tt := range tests {
+		if got := utils.GetMergeStrategy(cfg, tt.branchType, tt.target); got != tt.want {
+			t.Errorf("GetMergeStrategy(%s, %s) = %s, want %s", tt.branchType, tt.target, got, tt.want)
+		}
+	}
+
+	message := utils.RenderMergeMessage(cfg, utils.MergeMessageData{
+		Branch: "feature/PAY-1-login",
+		Type:   utils.TypeFeature,
+		Ticket: "PAY-1",
+		Author: "Jane <jane@example.com>",
+		Target: "develop",
+	})
+	if message != "PAY-1: feature/PAY-1-login (Jane <jane@example.com>)" {
+		t.Errorf("unexpected merge message '%s'", message)
+	}
+
+	invalid := "workflow:\n    merge_strategy:\n        feature: octopus\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, ".dflow.yaml"), []byte(invalid), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	if _, err := utils.LoadConfig(); err == nil {
+		t.Error("expected LoadConfig to reject an unknown merge strategy")
+	}
+}
+
+// TestSquashConflict checks that a squash merge stopping on conflicts reports them with a
+// SquashConflictError, since Git records no merge in progress for it.
+func TestSquashConflict(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", "-b", "develop", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	write := func(content string) {
+		if err := os.WriteFile("app.txt", []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("commit", "-q", "-am", content)
+	}
+
+	git("config", "user.name", "t")
+	git("config", "user.email", "t@e.x")
+	if err := os.WriteFile("app.txt", []byte("init"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", "app.txt")
+	git("commit", "-q", "-m", "init")
+	git("checkout", "-q", "-b", "feature/login")
+	write("login")
+	git("checkout", "-q", "develop")
+	write("develop")
+
+	err := gitutils.MergeBranch("feature/login", "develop", utils.MergeSquash, "feat: login")
+	var conflict *gitutils.SquashConflictError
+	if !errors.As(err, &conflict) {
+		t.Fatalf("MergeBranch() error = %v, want a SquashConflictError", err)
+	}
+	if !reflect.DeepEqual(conflict.Paths, []string{"app.txt"}) || conflict.Message != "feat: login" {
+		t.Errorf("conflict = %+v, want app.txt and the squash message", conflict)
+	}
+	if op := gitutils.OperationInProgress(); op != "" {
+		t.Errorf("OperationInProgress() = %q, want none for a squash merge", op)
+	}
+}
@@ -0,0 +1,60 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// TestRenderBranchName verifies that naming templates expand every supported placeholder.
+func TestRenderBranchName(t *testing.T) {
+	got := utils.RenderBranchName("{prefix}{ticket}-{slug}", "feature/", "feature", "PAY-123", "login-form")
+	if got != "feature/PAY-123-login-form" {
+		t.Errorf("expected 'feature/PAY-123-login-form', got '%s'", got)
+	}
+
+	got = utils.RenderBranchName(utils.DefaultNamingTemplate, "release/", "release", "", "1.2.0")
+	if got != "release/1.2.0" {
+		t.Errorf("expected 'release/1.2.0', got '%s'", got)
+	}
+}
+
+// TestValidateBranchPolicy checks ticket, pattern and length enforcement of naming rules.
+func TestValidateBranchPolicy(t *testing.T) {
+	feature := utils.NamingRule{
+		Template:      "{prefix}{ticket}-{slug}",
+		TicketPattern: "[A-Z]+-[0-9]+",
+		MaxLength:     30,
+	}
+	release := utils.NamingRule{
+		Template: utils.DefaultNamingTemplate,
+		Pattern:  `v?[0-9]+\.[0-9]+\.[0-9]+`,
+	}
+
+	tests := []struct {
+		name    string
+		rule    utils.NamingRule
+		branch  string
+		slug    string
+		ticket  string
+		wantErr bool
+	}{
+		{"valid feature", feature, "feature/PAY-123-login", "login", "PAY-123", false},
+		{"missing ticket", feature, "feature/-login", "login", "", true},
+		{"ticket not matching", feature, "feature/pay123-login", "login", "pay123", true},
+		{"too long", feature, "feature/PAY-123-a-very-long-branch-name", "a-very-long-branch-name", "PAY-123", true},
+		{"semver release", release, "release/1.4.0", "1.4.0", "", false},
+		{"non-semver release", release, "release/next", "next", "", true},
+		{"unknown placeholder", utils.NamingRule{Template: "{prefix}{team}-{slug}"}, "feature/{team}-x", "x", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validators.ValidateBranchPolicy(tt.rule, tt.branch, tt.slug, tt.ticket)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateBranchPolicy(%q) error = %v, wantErr %v", tt.branch, err, tt.wantErr)
+			}
+		})
+	}
+}
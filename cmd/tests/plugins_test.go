@@ -0,0 +1,113 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/plugins"
+)
+
+// TestDiscoverPlugins checks that dflow-<name> executables on PATH are discovered
+// together with their completion helpers, and that other files are ignored.
+func TestDiscoverPlugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	bin := t.TempDir()
+	files := map[string]os.FileMode{
+		"dflow-deploy-preview":          0755,
+		"dflow_complete-deploy-preview": 0755,
+		"dflow-not-executable":          0644,
+		"other-tool":                    0755,
+	}
+	for name, mode := range files {
+		if err := os.WriteFile(filepath.Join(bin, name), []byte("#!/bin/sh\necho ok\n"), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Setenv("PATH", bin)
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	found := plugins.Discover()
+	if len(found) != 1 {
+		t.Fatalf("expected 1 plugin, got %+v", found)
+	}
+
+	plugin := found[0]
+	if plugin.Name != "deploy-preview" {
+		t.Errorf("expected plugin 'deploy-preview', got '%s'", plugin.Name)
+	}
+	if plugin.Completion != filepath.Join(bin, "dflow_complete-deploy-preview") {
+		t.Errorf("completion helper not found, got '%s'", plugin.Completion)
+	}
+	if got := plugin.Complete([]string{""}); len(got) != 1 || got[0] != "ok" {
+		t.Errorf("unexpected completions %v", got)
+	}
+}
+
+// TestRepoPluginCompletion checks that the completion helpers of the repository plugin
+// directory are only used once the user opts in through git config.
+func TestRepoPluginCompletion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin fixtures are shell scripts")
+	}
+
+	git, err := exec.LookPath("git")
+	if err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+	repo := t.TempDir()
+	if err := exec.Command(git, "init", "-q", repo).Run(); err != nil {
+		t.Fatal(err)
+	}
+	dir := filepath.Join(repo, plugins.Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"dflow-deploy", "dflow_complete-deploy"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\necho ok\n"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// only git on PATH, without the user's global configuration
+	t.Setenv("PATH", filepath.Dir(git))
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	deploy := func() *plugins.Plugin {
+		for _, plugin := range plugins.Discover() {
+			if plugin.Name == "deploy" {
+				return &plugin
+			}
+		}
+		t.Fatal("the repository plugin was not discovered")
+		return nil
+	}
+
+	if plugin := deploy(); plugin.Completion != "" {
+		t.Fatalf("expected no completion helper before opting in, got %s", plugin.Completion)
+	}
+
+	if err := exec.Command(git, "config", plugins.RepoCompletionsKey, "true").Run(); err != nil {
+		t.Fatal(err)
+	}
+	if plugin := deploy(); plugin.Completion != filepath.Join(dir, "dflow_complete-deploy") {
+		t.Errorf("expected the completion helper once enabled, got '%s'", plugin.Completion)
+	}
+}
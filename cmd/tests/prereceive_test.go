@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+)
+
+// TestPreReceiveHook runs the generated server hook against ref updates of a repository
+// whose .dflow.yaml protects main.
+func TestPreReceiveHook(t *testing.T) {
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	config := `branches:
+    main: main
+    develop: develop
+    features: feature/
+flow:
+    feature_base: develop
+workflow:
+    default_merge_mode: auto
+    branch_rules:
+        main: manual
+versioning:
+    tag_prefix: "v"
+`
+	git("init", "-q", "-b", "main")
+	if err := os.WriteFile(filepath.Join(dir, ".dflow.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("add", ".dflow.yaml")
+	git("commit", "-q", "-m", "init")
+	first := git("rev-parse", "HEAD")
+	git("commit", "-q", "--allow-empty", "-m", "second")
+	second := git("rev-parse", "HEAD")
+
+	// a commit carrying a looser configuration: new refs are checked against it, while
+	// existing branches keep the rules of their tip before the push
+	git("checkout", "-q", "-b", "loose")
+	loosened := strings.Replace(config, "features: feature/", "features: wip/", 1)
+	loosened = strings.Replace(loosened, "main: manual", "main: auto", 1)
+	if err := os.WriteFile(filepath.Join(dir, ".dflow.yaml"), []byte(loosened), 0644); err != nil {
+		t.Fatal(err)
+	}
+	git("commit", "-q", "-am", "loosen")
+	loose := git("rev-parse", "HEAD")
+	git("checkout", "-q", "main")
+
+	hook := filepath.Join(t.TempDir(), "pre-receive")
+	if err := os.WriteFile(hook, []byte(gitutils.PreReceiveHook()), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	zero := strings.Repeat("0", 40)
+	tests := []struct {
+		name   string
+		update string
+		env    string
+		allow  bool
+	}{
+		{"feature branch", zero + " " + second + " refs/heads/feature/login", "", true},
+		{"support branch", zero + " " + second + " refs/heads/support/1.x", "", true},
+		{"unknown prefix", zero + " " + second + " refs/heads/wip/login", "", false},
+		{"new branch with its own config", zero + " " + loose + " refs/heads/wip/login", "", true},
+		{"manual branch loosening its rules", second + " " + loose + " refs/heads/main", "", false},
+		{"tag with its own config", zero + " " + loose + " refs/tags/latest", "", false},
+		{"manual branch", first + " " + second + " refs/heads/main", "", false},
+		{"manual branch allowed", first + " " + second + " refs/heads/main", "DFLOW_ALLOW_MANUAL=1", true},
+		{"base fast-forward", first + " " + second + " refs/heads/develop", "", true},
+		{"base non-fast-forward", second + " " + first + " refs/heads/develop", "", false},
+		{"base deletion", second + " " + zero + " refs/heads/develop", "", false},
+		{"version tag", zero + " " + second + " refs/tags/v1.2.0-rc.1", "", true},
+		{"invalid tag", zero + " " + second + " refs/tags/latest", "", false},
+		{"promotion tag", zero + " " + second + " refs/tags/uat-2026-10-16.1", "", true},
+		{"promotion tag without number", zero + " " + second + " refs/tags/uat-2026-10-16", "", false},
+	}
+
+	for _, tt := range tests {
+		cmd := exec.Command("sh", hook)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_DIR="+filepath.Join(dir, ".git"), tt.env)
+		cmd.Stdin = strings.NewReader(tt.update + "\n")
+		out, err := cmd.CombinedOutput()
+		if (err == nil) != tt.allow {
+			t.Errorf("%s: allowed = %v, want %v\n%s", tt.name, err == nil, tt.allow, out)
+		}
+	}
+}
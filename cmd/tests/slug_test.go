@@ -0,0 +1,39 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/pkg/slug"
+)
+
+// TestSlugMake verifies transliteration, punctuation stripping, separator collapsing
+// and word-boundary truncation of branch name slugs.
+func TestSlugMake(t *testing.T) {
+	tests := []struct {
+		input string
+		opts  slug.Options
+		want  string
+	}{
+		{"login form", slug.Options{}, "login-form"},
+		{"  login    screen   bug ", slug.Options{}, "login-screen-bug"},
+		{"Añadir pantalla de éxito!", slug.Options{}, "anadir-pantalla-de-exito"},
+		{"Straße & Øresund", slug.Options{}, "strasse-oresund"},
+		{"fix: crash (iOS) -- again", slug.Options{}, "fix-crash-ios-again"},
+		{"v1.2.0", slug.Options{}, "v1.2.0"},
+		{"release 2.0. final", slug.Options{}, "release-2.0-final"},
+		{"..hidden..name..", slug.Options{}, "hidden-name"},
+		{"日本語 title", slug.Options{}, "title"},
+		{"Añadir éxito", slug.Options{KeepUnicode: true}, "añadir-éxito"},
+		{"add a very long feature name", slug.Options{MaxLength: 14}, "add-a-very"},
+		{"supercalifragilistic", slug.Options{MaxLength: 5}, "super"},
+		{"!!!", slug.Options{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := slug.Make(tt.input, tt.opts); got != tt.want {
+				t.Errorf("slug.Make(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
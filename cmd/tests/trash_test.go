@@ -0,0 +1,98 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+)
+
+// TestTrash checks that backups of deleted branches are kept apart, even when taken
+// within the same second, listed, restored and purged by age.
+func TestTrash(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", "-b", "main", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	git("commit", "-q", "--allow-empty", "-m", "init")
+	git("branch", "feature/login")
+	commit := git("rev-parse", "feature/login")
+
+	// a delete/restore/delete cycle in a script saves the same branch twice in a row
+	first, err := gitutils.SaveToTrash("feature/login", "refs/heads/feature/login", "origin/feature/login", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := gitutils.SaveToTrash("feature/login", "refs/heads/feature/login", "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.ID() == second.ID() {
+		t.Fatalf("two backups share the ID %s", first.ID())
+	}
+
+	entries, err := gitutils.ListTrash("feature/login")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Ref != second.Ref || entries[1].Ref != first.Ref {
+		t.Fatalf("ListTrash = %+v, want the two backups, newest first", entries)
+	}
+	if entries[1].Upstream != "origin/feature/login" || entries[0].Upstream != "" {
+		t.Errorf("upstreams = %q, %q; want \"\", origin/feature/login", entries[0].Upstream, entries[1].Upstream)
+	}
+	if others, _ := gitutils.ListTrash("feature/other"); len(others) != 0 {
+		t.Errorf("ListTrash(feature/other) = %+v, want none", others)
+	}
+
+	if err := gitutils.RestoreFromTrash(entries[0], "feature/login"); err == nil {
+		t.Error("RestoreFromTrash should refuse an existing branch")
+	}
+	git("branch", "-D", "feature/login")
+	if err := gitutils.RestoreFromTrash(entries[0], "feature/login"); err != nil {
+		t.Fatal(err)
+	}
+	if got := git("rev-parse", "feature/login"); got != commit {
+		t.Errorf("restored branch at %s, want %s", got, commit)
+	}
+
+	// backups made before the cutoff are the only ones purged
+	git("update-ref", "refs/dflow/trash/feature/login/20200101-000000", commit)
+	entries, _ = gitutils.ListTrash("")
+	old := gitutils.TrashOlderThan(entries, 30, time.Now())
+	if len(entries) != 3 || len(old) != 1 || old[0].ID() != "20200101-000000" {
+		t.Fatalf("TrashOlderThan = %+v, want only the 2020 backup of %+v", old, entries)
+	}
+
+	for _, entry := range []gitutils.TrashEntry{old[0], *first} {
+		if err := gitutils.RemoveFromTrash(entry, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if entries, _ = gitutils.ListTrash(""); len(entries) != 1 || entries[0].Ref != second.Ref {
+		t.Errorf("after removal ListTrash = %+v, want only %s", entries, second.Ref)
+	}
+	if exec.Command("git", "config", "--get-regexp", `^dflow-trash\.`).Run() == nil {
+		t.Error("the upstream of the removed backup is still recorded")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/yepizrene-devoost/dflow/cmd/utils"
 )
@@ -48,3 +49,84 @@ func TestSaveAndLoadConfig(t *testing.T) {
 		t.Errorf("expected main branch '%s', got '%s'", original.Branches.Main, loaded.Branches.Main)
 	}
 }
+
+// TestProtectedBranches verifies that every configured base branch is reported as
+// protected exactly once, and that flow branches are not.
+func TestProtectedBranches(t *testing.T) {
+	cfg := &utils.Config{}
+	cfg.Branches.Main = "main"
+	cfg.Branches.Develop = "develop"
+	cfg.Branches.Uat = "uat"
+	cfg.Flow.FeatureBase = "uat"
+	cfg.Flow.FeatureMerge = "develop"
+	cfg.Flow.HotfixBase = "main"
+
+	bases := utils.BaseBranches(cfg)
+	if len(bases) != 3 {
+		t.Fatalf("expected 3 base branches, got %v", bases)
+	}
+
+	for _, branch := range []string{"main", "develop", "uat"} {
+		if !utils.IsProtectedBranch(cfg, branch) {
+			t.Errorf("expected '%s' to be protected", branch)
+		}
+	}
+
+	if utils.IsProtectedBranch(cfg, "feature/login-form") {
+		t.Errorf("expected 'feature/login-form' not to be protected")
+	}
+}
+
+// TestPromotionChain checks the promotions allowed by the chain, the rendering of
+// promotion tags and the validation of the promotion section.
+func TestPromotionChain(t *testing.T) {
+	cfg := &utils.Config{}
+	cfg.Branches.Main = "main"
+	cfg.Branches.Develop = "develop"
+	cfg.Branches.Uat = "uat"
+
+	tests := []struct {
+		from, to string
+		want     bool
+	}{
+		{"develop", "uat", true},
+		{"uat", "main", true},
+		{"develop", "main", false},
+		{"main", "uat", false},
+		{"main", "", false},
+	}
+	for _, tt := range tests {
+		if got := utils.IsPromotion(cfg, tt.from, tt.to); got != tt.want {
+			t.Errorf("IsPromotion(%q, %q) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	date := time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC)
+	if got := utils.RenderPromotionTag(cfg, "develop", "uat", date, 2); got != "uat-2026-10-16.2" {
+		t.Errorf("RenderPromotionTag = %q, want uat-2026-10-16.2", got)
+	}
+
+	cfg.Promotion.Chain = []string{"develop", "main"}
+	if !utils.IsPromotion(cfg, "develop", "main") || utils.IsPromotion(cfg, "develop", "uat") {
+		t.Error("promotion.chain should replace the default chain")
+	}
+	if err := utils.ValidateConfig(cfg); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	invalid := []func(cfg *utils.Config){
+		func(cfg *utils.Config) { cfg.Promotion.Chain = []string{"develop", "staging"} },
+		func(cfg *utils.Config) { cfg.Promotion.Chain = []string{"develop", "uat", "develop"} },
+		func(cfg *utils.Config) { cfg.Promotion.Strategy = utils.MergeSquash },
+		func(cfg *utils.Config) { cfg.Promotion.TagFormat = "{to}-{date}" },
+		func(cfg *utils.Config) { cfg.Promotion.TagFormat = "{to} {date}.{n}" },
+	}
+	for i, change := range invalid {
+		broken := *cfg
+		broken.Promotion.Chain = nil
+		change(&broken)
+		if err := utils.ValidateConfig(&broken); err == nil {
+			t.Errorf("case %d: expected an invalid promotion section to be rejected", i)
+		}
+	}
+}
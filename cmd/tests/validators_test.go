@@ -0,0 +1,137 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/validators"
+)
+
+// branchNameCorpus lists branch names with the verdict of Git itself, as given by
+// `git check-ref-format --allow-onelevel <name>` combined with the branch-only rules
+// (no leading '-', not "HEAD").
+var branchNameCorpus = []struct {
+	name  string
+	valid bool
+}{
+	{"main", true},
+	{"feature/login-form", true},
+	{"release/v1.2.0", true},
+	{"a.b", true},
+	{"a/b.lock.c", true},
+	{"x@", true},
+	{"@x", true},
+	{"a@b", true},
+	{"a/@/b", true},
+	{"HEAD/x", true},
+	{"x/HEAD", true},
+	{"a$b", true},
+	{"a#b", true},
+	{"a{b}", true},
+	{"a'b", true},
+	{"a\"b", true},
+	{"a<b>", true},
+	{"a|b", true},
+	{"a!b", true},
+	{"a%b", true},
+	{"a,b;c=d+e", true},
+	{"ñandú", true},
+	{"", false},
+	{"@", false},
+	{"HEAD", false},
+	{"-x", false},
+	{"-", false},
+	{".x", false},
+	{"x/.hidden", false},
+	{"a/.", false},
+	{"x.lock", false},
+	{"x.lock/y", false},
+	{"a/.lock", false},
+	{".lock", false},
+	{"a b", false},
+	{"a\tb", false},
+	{"a\x7fb", false},
+	{"a..b", false},
+	{"a~b", false},
+	{"a^b", false},
+	{"a:b", false},
+	{"a?b", false},
+	{"a*b", false},
+	{"a[b", false},
+	{"a\\b", false},
+	{"a@{b", false},
+	{"x/", false},
+	{"/x", false},
+	{"a//b", false},
+	{"a.", false},
+}
+
+// TestIsValidGitBranchName checks the validator against the corpus and, when Git is
+// available, against `git check-ref-format` itself.
+func TestIsValidGitBranchName(t *testing.T) {
+	_, err := exec.LookPath("git")
+	hasGit := err == nil
+
+	for _, tt := range branchNameCorpus {
+		t.Run(tt.name, func(t *testing.T) {
+			valid, reason := validators.IsValidGitBranchName(tt.name)
+			if valid != tt.valid {
+				t.Errorf("IsValidGitBranchName(%q) = %v (%s), want %v", tt.name, valid, reason, tt.valid)
+			}
+
+			if !hasGit || tt.name == "" {
+				return
+			}
+
+			gitValid := exec.Command("git", "check-ref-format", "--allow-onelevel", tt.name).Run() == nil &&
+				!strings.HasPrefix(tt.name, "-") && tt.name != "HEAD"
+			if valid != gitValid {
+				t.Errorf("IsValidGitBranchName(%q) = %v, git check-ref-format says %v", tt.name, valid, gitValid)
+			}
+		})
+	}
+}
+
+// TestLoadConfigRejectsInvalidRefs verifies that prefixes and base branches in
+// .dflow.yaml go through the same ref-format validation as branch names.
+func TestLoadConfigRejectsInvalidRefs(t *testing.T) {
+	tests := []struct {
+		name string
+		edit func(cfg *utils.Config)
+	}{
+		{"prefix with space", func(cfg *utils.Config) { cfg.Branches.Features = "my feature/" }},
+		{"prefix with dot component", func(cfg *utils.Config) { cfg.Branches.Releases = "release/.x/" }},
+		{"base ending in .lock", func(cfg *utils.Config) { cfg.Branches.Main = "main.lock" }},
+		{"flow base with '..'", func(cfg *utils.Config) { cfg.Flow.HotfixBase = "main..x" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			os.Setenv("DFLOW_CWD", tmpDir)
+			defer os.Unsetenv("DFLOW_CWD")
+
+			cfg := &utils.Config{}
+			cfg.Branches.Main = "main"
+			cfg.Branches.Develop = "develop"
+			cfg.Branches.Features = "feature/"
+			cfg.Branches.Releases = "release/"
+			tt.edit(cfg)
+
+			if err := utils.SaveConfig(cfg); err != nil {
+				t.Fatalf("failed to save config: %v", err)
+			}
+			if _, err := os.Stat(filepath.Join(tmpDir, ".dflow.yaml")); err != nil {
+				t.Fatalf("config not written: %v", err)
+			}
+
+			if _, err := utils.LoadConfig(); err == nil {
+				t.Errorf("expected LoadConfig to reject the configuration")
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package tests
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/cmd/versioning"
+	"github.com/yepizrene-devoost/dflow/pkg/calver"
+	"github.com/yepizrene-devoost/dflow/pkg/semver"
+)
+
+// TestSemver checks parsing, precedence and increments of semantic versions.
+func TestSemver(t *testing.T) {
+	if _, err := semver.Parse("1.2"); err == nil {
+		t.Error("Parse(1.2) should fail")
+	}
+
+	ordered := []string{"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0", "1.0.1", "1.10.0"}
+	for i := 1; i < len(ordered); i++ {
+		a, _ := semver.Parse(ordered[i-1])
+		b, _ := semver.Parse(ordered[i])
+		if semver.Compare(a, b) != -1 || semver.Compare(b, a) != 1 {
+			t.Errorf("expected %s < %s", ordered[i-1], ordered[i])
+		}
+	}
+
+	bumps := []struct{ version, increment, want string }{
+		{"1.2.3", semver.Patch, "1.2.4"},
+		{"1.2.3+7", semver.Minor, "1.3.0"},
+		{"1.2.3", semver.Major, "2.0.0"},
+		{"1.3.0-rc.1", semver.Minor, "1.3.0"},
+		{"1.3.1-rc.1", semver.Minor, "1.4.0"},
+		{"1.2.3", semver.None, "1.2.3"},
+	}
+	for _, tt := range bumps {
+		v, _ := semver.Parse(tt.version)
+		got, err := v.Bump(tt.increment)
+		if err != nil || got.String() != tt.want {
+			t.Errorf("Bump(%s, %s) = %s, %v; want %s", tt.version, tt.increment, got, err, tt.want)
+		}
+	}
+}
+
+// TestComputeVersion checks the versions derived on each kind of branch.
+func TestComputeVersion(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commits := func(n int) {
+		for i := 0; i < n; i++ {
+			git("commit", "-q", "--allow-empty", "-m", "change")
+		}
+	}
+
+	cfg := &utils.Config{}
+	cfg.Branches.Main = "main"
+	cfg.Branches.Develop = "develop"
+	cfg.Branches.Features = "feature/"
+	cfg.Branches.Releases = "release/"
+	cfg.Versioning.Rules = map[string]utils.VersionRule{utils.TypeRelease: {Label: "beta"}}
+
+	commits(1)
+	git("tag", "v1.2.0")
+	git("tag", "not-a-version")
+
+	tests := []struct {
+		branch  string
+		commits int
+		want    string
+	}{
+		{"main", 0, "1.2.0"},
+		{"main", 2, "1.2.1+2"},
+		{"develop", 1, "1.3.0-alpha.3"},
+		{"feature/login", 2, "1.3.0-alpha.feature-login.5"},
+		{"release/2.0.0", 1, "2.0.0-beta.6"},
+	}
+	for _, tt := range tests {
+		commits(tt.commits)
+		result, err := versioning.Compute(cfg, tt.branch, "HEAD")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.Version != tt.want {
+			t.Errorf("Compute(%s) = %s, want %s", tt.branch, result.Version, tt.want)
+		}
+	}
+}
+
+// TestReleaseCandidates checks candidate numbering with a custom scheme and the grouping
+// of tags into release lines.
+func TestReleaseCandidates(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	env := append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+	for _, args := range [][]string{
+		{"commit", "-q", "--allow-empty", "-m", "init"},
+		{"tag", "v1.2.0"},
+		{"tag", "v1.3.0-beta1"},
+		{"tag", "v1.3.0-beta2"},
+		{"tag", "v1.3.0-alpha.1"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Env = env
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+
+	cfg := &utils.Config{}
+	cfg.Versioning.Candidates.Label = "beta"
+	cfg.Versioning.Candidates.Format = "{label}{n}"
+
+	if tag, number := versioning.NextCandidate(cfg, "1.3.0"); tag != "v1.3.0-beta3" || number != 3 {
+		t.Errorf("NextCandidate = %s, %d; want v1.3.0-beta3, 3", tag, number)
+	}
+
+	if tag, _ := versioning.NextCandidate(cfg, "1.4.0"); tag != "v1.4.0-beta1" {
+		t.Errorf("NextCandidate = %s, want v1.4.0-beta1", tag)
+	}
+
+	releases := versioning.Releases(cfg)
+	if len(releases) != 2 || releases[0].Version != "1.3.0" || len(releases[0].Candidates) != 2 || releases[1].Final == nil {
+		t.Errorf("unexpected releases: %+v", releases)
+	}
+}
+
+// TestCalverReleaseCandidates checks that release lines, candidates and the version of
+// release branches follow zero-padded and four-part calver formats, and that build
+// versions are refused for them.
+func TestCalverReleaseCandidates(t *testing.T) {
+	repo := t.TempDir()
+	if err := exec.Command("git", "init", "-q", repo).Run(); err != nil {
+		t.Skipf("git not available: %v", err)
+	}
+
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	if err := os.Chdir(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@e.x", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@e.x")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	git("commit", "-q", "--allow-empty", "-m", "init")
+
+	tests := []struct {
+		format   string
+		released string
+		version  string
+	}{
+		{"YYYY.0M.MICRO", "2025.12.3", "2026.01.1"},
+		{"YYYY.0M.0D.MICRO", "2025.12.31.0", "2026.01.09.1"},
+	}
+	for _, tt := range tests {
+		git("tag", "v"+tt.released)
+		git("tag", "v"+tt.version+"-rc.1")
+		git("branch", "release/"+tt.version)
+
+		cfg := &utils.Config{}
+		cfg.Branches.Releases = "release/"
+		cfg.Versioning.Scheme = utils.SchemeCalver
+		cfg.Versioning.CalverFormat = tt.format
+
+		if version, err := versioning.ReleaseVersion(cfg, "release/"+tt.version); err != nil || version != tt.version {
+			t.Errorf("%s: ReleaseVersion = %s, %v; want %s", tt.format, version, err, tt.version)
+		}
+		if tag, number := versioning.NextCandidate(cfg, tt.version); tag != "v"+tt.version+"-rc.2" || number != 2 {
+			t.Errorf("%s: NextCandidate = %s, %d; want v%s-rc.2, 2", tt.format, tag, number, tt.version)
+		}
+
+		releases := versioning.Releases(cfg)
+		if len(releases) != 2 || releases[0].Version != tt.version || releases[0].Branch != "release/"+tt.version ||
+			len(releases[0].Candidates) != 1 || releases[1].Version != tt.released || releases[1].Final == nil {
+			t.Errorf("%s: unexpected releases: %+v", tt.format, releases)
+		}
+
+		if _, err := versioning.Compute(cfg, "release/"+tt.version, "HEAD"); err == nil {
+			t.Errorf("%s: Compute should fail with the calver scheme", tt.format)
+		}
+	}
+}
+
+// TestCalver checks calendar version formats, release numbering and hotfix increments.
+func TestCalver(t *testing.T) {
+	for _, format := range []string{"YYYY.MICRO.MM", "YYYY.Q.MICRO"} {
+		if _, err := calver.ParseFormat(format); err == nil {
+			t.Errorf("ParseFormat(%s) should fail", format)
+		}
+	}
+
+	format, err := calver.ParseFormat("YYYY.0M.MICRO")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !format.Matches("2026.01.3") || format.Matches("2026.1.3") || format.Matches("2026.13.0") {
+		t.Error("unexpected Matches results for YYYY.0M.MICRO")
+	}
+
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+	if next, _ := format.Next(now, []string{"2026.09.4"}); next != "2026.10.0" {
+		t.Errorf("Next = %s, want 2026.10.0", next)
+	}
+	if next, _ := format.Next(now, []string{"2026.10.0", "2026.10.1", "2026.09.7"}); next != "2026.10.2" {
+		t.Errorf("Next = %s, want 2026.10.2", next)
+	}
+	if hotfix, _ := format.BumpMicro("2026.09.7"); hotfix != "2026.09.8" {
+		t.Errorf("BumpMicro = %s, want 2026.09.8", hotfix)
+	}
+
+	daily, _ := calver.ParseFormat("0Y.0M.0D")
+	if _, err := daily.Next(now, []string{"26.10.18"}); err == nil {
+		t.Error("Next should fail when the period is released and the format has no MICRO")
+	}
+}
+
+// TestValidateReleaseName checks release names against each versioning scheme.
+func TestValidateReleaseName(t *testing.T) {
+	tests := []struct {
+		scheme string
+		name   string
+		valid  bool
+	}{
+		{utils.SchemeSemver, "1.3.0", true},
+		{utils.SchemeSemver, "v1.3.0", true},
+		{utils.SchemeSemver, "1.3.0-rc.1", false},
+		{utils.SchemeSemver, "sprint-42", false},
+		{utils.SchemeCalver, "2026.10.1", true},
+		{utils.SchemeCalver, "1.3.0", false},
+		{utils.SchemeFree, "sprint-42", true},
+	}
+
+	for _, tt := range tests {
+		cfg := &utils.Config{}
+		cfg.Versioning.Scheme = tt.scheme
+		err := versioning.ValidateReleaseName(cfg, tt.name)
+		if (err == nil) != tt.valid {
+			t.Errorf("ValidateReleaseName(%s, %q) error = %v, want valid=%v", tt.scheme, tt.name, err, tt.valid)
+		}
+	}
+}
+
+// TestBumpVersionFiles checks that every locator rewrites only the version and that
+// locators matching nothing fail.
+func TestBumpVersionFiles(t *testing.T) {
+	files := []struct {
+		file    utils.VersionFile
+		content string
+		want    string
+	}{
+		{utils.VersionFile{Path: "VERSION"}, "1.2.0\n", "1.3.0\n"},
+		{utils.VersionFile{Path: "package.json", JSON: "version"},
+			"{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"9.9.9\"},\n  \"version\": \"1.2.0\"\n}\n",
+			"{\n  \"name\": \"app\",\n  \"dependencies\": {\"version\": \"9.9.9\"},\n  \"version\": \"1.3.0\"\n}\n"},
+		{utils.VersionFile{Path: "Chart.yaml", YAML: "appVersion"},
+			"name: app\nversion: 0.4.0\nappVersion: \"1.2.0\" # app\n",
+			"name: app\nversion: 0.4.0\nappVersion: \"1.3.0\" # app\n"},
+		{utils.VersionFile{Path: "values.yaml", YAML: "images.1.tag"},
+			"images:\n  - tag: latest\n  - tag: 1.2.0\n",
+			"images:\n  - tag: latest\n  - tag: 1.3.0\n"},
+		{utils.VersionFile{Path: "version.go", Regex: `const Version = "([^"]+)"`},
+			"package main\n\nconst Version = \"1.2.0\"\n",
+			"package main\n\nconst Version = \"1.3.0\"\n"},
+	}
+	for _, tt := range files {
+		got, err := versioning.BumpContent(tt.file, []byte(tt.content), "1.3.0")
+		if err != nil || string(got) != tt.want {
+			t.Errorf("%s: got %q, %v; want %q", tt.file.Path, got, err, tt.want)
+		}
+	}
+
+	missing := []struct {
+		file    utils.VersionFile
+		content string
+	}{
+		{utils.VersionFile{Path: "VERSION"}, "\n"},
+		{utils.VersionFile{Path: "package.json", JSON: "app.version"}, `{"version": "1.2.0"}`},
+		{utils.VersionFile{Path: "package.json", JSON: "version"}, `{"version": 1}`},
+		{utils.VersionFile{Path: "Chart.yaml", YAML: "appVersion"}, "version: 1.2.0\n"},
+		{utils.VersionFile{Path: "version.go", Regex: `Version = "([^"]+)"`}, "package main\n"},
+	}
+	for _, tt := range missing {
+		if _, err := versioning.BumpContent(tt.file, []byte(tt.content), "1.3.0"); err == nil {
+			t.Errorf("%s (%s) should fail on %q", tt.file.Path, tt.file.Locator(), tt.content)
+		}
+	}
+
+	// nothing is written when one of the files fails
+	dir := t.TempDir()
+	if err := os.WriteFile(dir+"/VERSION", []byte("1.2.0\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := &utils.Config{VersionFiles: []utils.VersionFile{{Path: "VERSION"}, {Path: "missing.json", JSON: "version"}}}
+	if _, err := versioning.BumpFiles(cfg, dir, "1.3.0"); err == nil {
+		t.Error("BumpFiles should fail when a version file is missing")
+	}
+	if content, _ := os.ReadFile(dir + "/VERSION"); string(content) != "1.2.0\n" {
+		t.Errorf("VERSION was rewritten to %q despite the failure", content)
+	}
+}
@@ -11,7 +11,12 @@ package utils
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/yepizrene-devoost/dflow/pkg/refformat"
 	"gopkg.in/yaml.v3"
 )
 
@@ -21,13 +26,15 @@ import (
 // It includes branching information, flow rules, and merge behavior.
 type Config struct {
 	Branches struct {
-		Main     string `yaml:"main"`
-		Develop  string `yaml:"develop"`
-		Uat      string `yaml:"uat"`
-		Features string `yaml:"features"`
-		Releases string `yaml:"releases"`
-		Hotfixes string `yaml:"hotfixes"`
-		Bugfixes string `yaml:"bugfixes"`
+		Main      string `yaml:"main"`
+		Develop   string `yaml:"develop"`
+		Uat       string `yaml:"uat"`
+		Features  string `yaml:"features"`
+		Releases  string `yaml:"releases"`
+		Hotfixes  string `yaml:"hotfixes"`
+		Bugfixes  string `yaml:"bugfixes"`
+		Supports  string `yaml:"supports,omitempty"`  // prefix of support branches, "support/" when empty
+		Backports string `yaml:"backports,omitempty"` // prefix of backport branches, "backport/" when empty
 	} `yaml:"branches"`
 
 	Flow struct {
@@ -39,11 +46,112 @@ type Config struct {
 	} `yaml:"flow"`
 
 	Workflow struct {
-		DefaultMergeMode string            `yaml:"default_merge_mode"`
-		BranchRules      map[string]string `yaml:"branch_rules"` // e.g., {"main": "manual", "develop": "auto"}
+		DefaultMergeMode string                       `yaml:"default_merge_mode"`
+		BranchRules      map[string]string            `yaml:"branch_rules"`             // e.g., {"main": "manual", "develop": "auto"}
+		SyncStrategy     map[string]string            `yaml:"sync_strategy,omitempty"`  // per branch type: "merge" or "rebase"
+		MergeStrategy    map[string]MergeStrategyRule `yaml:"merge_strategy,omitempty"` // per branch type and target, used by `dflow finish`
+		MergeMessage     map[string]string            `yaml:"merge_message,omitempty"`  // per branch type merge commit message template
 	} `yaml:"workflow"`
+
+	Naming map[string]NamingRule `yaml:"naming,omitempty"` // keyed by branch type (feature, release, hotfix, bugfix)
+
+	Gates map[string][]Gate `yaml:"gates,omitempty"` // commands `dflow finish` runs before merging, keyed by branch type
+
+	Hooks map[string][]string `yaml:"hooks,omitempty"` // lifecycle hook commands keyed by hook name (pre-start, post-finish, ...)
+
+	Versioning struct {
+		Scheme       string `yaml:"scheme"`        // semver (default), calver or free
+		CalverFormat string `yaml:"calver_format"` // calver tokens, e.g. "YYYY.MM.MICRO"
+
+		TagPrefix  string                 `yaml:"tag_prefix"`      // prefix of version tags, "v" when empty
+		TagPattern string                 `yaml:"tag_pattern"`     // regexp (POSIX ERE compatible) version tags must match
+		Rules      map[string]VersionRule `yaml:"rules,omitempty"` // per branch type (and main, develop, default), used by `dflow version compute`
+
+		BumpMessage string `yaml:"bump_message,omitempty"` // commit message template of version file bumps
+
+		Candidates struct {
+			Label  string `yaml:"label"`  // pre-release label of release candidates, "rc" when empty
+			Format string `yaml:"format"` // numbering scheme of the pre-release, "{label}.{n}" when empty
+			Start  int    `yaml:"start"`  // number of the first candidate, 1 when unset
+		} `yaml:"candidates,omitempty"` // release candidate tags created by `dflow release rc`
+	} `yaml:"versioning,omitempty"`
+
+	VersionFiles []VersionFile `yaml:"version_files,omitempty"` // files rewritten with the new version by `dflow start release` and `dflow finish hotfix`
+
+	Commits struct {
+		Pattern string `yaml:"pattern"` // regexp the commit subject must match (Conventional Commits when empty)
+	} `yaml:"commits,omitempty"`
+
+	Slug struct {
+		KeepUnicode bool `yaml:"keep_unicode"` // keep accented/non-Latin letters instead of transliterating
+		MaxLength   int  `yaml:"max_length"`   // truncate names on word boundaries (0 = no limit)
+	} `yaml:"slug,omitempty"`
+
+	Worktrees struct {
+		Enabled bool   `yaml:"enabled"` // start branches in a new worktree by default
+		Dir     string `yaml:"dir"`     // parent directory of the worktrees, relative to the repository root
+	} `yaml:"worktrees,omitempty"`
+
+	Promotion struct {
+		Chain     []string `yaml:"chain,omitempty"`      // environment branches in promotion order, develop → uat → main when empty
+		Strategy  string   `yaml:"strategy,omitempty"`   // no-ff (default) or ff-only
+		Message   string   `yaml:"message,omitempty"`    // merge commit message template, "Merge {from} into {to}" when empty
+		Tag       bool     `yaml:"tag,omitempty"`        // tag every promotion (see `dflow promote --tag`)
+		TagFormat string   `yaml:"tag_format,omitempty"` // name of promotion tags, "{to}-{date}.{n}" when empty
+	} `yaml:"promotion,omitempty"` // environment promotions made by `dflow promote`
+
+	Trash struct {
+		Push          bool `yaml:"push"`           // also push backup refs to origin
+		RetentionDays int  `yaml:"retention_days"` // default retention used by `dflow trash purge`
+	} `yaml:"trash,omitempty"`
+}
+
+// NamingRule defines how the names of one branch type are built and the policy they must follow.
+//
+// Templates accept the placeholders {prefix}, {type}, {ticket} and {slug}. When a template
+// uses {ticket}, a ticket is required to start the branch.
+type NamingRule struct {
+	Template      string `yaml:"template"`       // e.g. "{prefix}{ticket}-{slug}"
+	TicketPattern string `yaml:"ticket_pattern"` // regexp the ticket must match, e.g. "[A-Z]+-[0-9]+"
+	Pattern       string `yaml:"pattern"`        // regexp the {slug} part must match, e.g. semver for releases
+	MaxLength     int    `yaml:"max_length"`     // maximum length of the full branch name (0 = no limit)
 }
 
+// Gate is a local command that must succeed before `dflow finish` merges a branch.
+//
+// The command runs through the shell from Dir (relative to the repository root), with
+// Env added to the environment, and is stopped after Timeout (a Go duration such as
+// "5m"; no limit when empty).
+type Gate struct {
+	Name    string            `yaml:"name"`    // label shown in the output and commit trailers (defaults to the command)
+	Run     string            `yaml:"run"`     // shell command, e.g. "make test"
+	Timeout string            `yaml:"timeout"` // e.g. "90s", "10m"
+	Dir     string            `yaml:"dir"`     // working directory relative to the repository root
+	Env     map[string]string `yaml:"env"`     // extra environment variables
+}
+
+// Label returns the name of the gate, or its command when it has no name.
+func (g Gate) Label() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return g.Run
+}
+
+// DefaultNamingTemplate keeps the historical naming: the type prefix followed by the name.
+const DefaultNamingTemplate = "{prefix}{slug}"
+
+// DefaultCommitPattern is the commit subject convention enforced by the commit-msg hook
+// when `commits.pattern` is not set: Conventional Commits, e.g. "feat(api): add login".
+const DefaultCommitPattern = `^(feat|fix|docs|style|refactor|perf|test|build|ci|chore|revert)(\([\w./-]+\))?!?: \S.*`
+
+// DefaultTagPrefix is the prefix of version tags when `versioning.tag_prefix` is not set.
+const DefaultTagPrefix = "v"
+
+// DefaultTrashRetentionDays is the retention applied by `dflow trash purge`
+// when `trash.retention_days` is not set in .dflow.yaml.
+const DefaultTrashRetentionDays = 30
+
 const bannerToConfig = `
 #
 #               ██████╗ ███████╗██╗      ██████╗ ██╗    ██╗
@@ -56,16 +164,22 @@ const bannerToConfig = `
 #            dflow config file - autogenerated by 'dflow init'
 `
 
-// LoadConfig reads and parses the .dflow.yaml configuration file
-// from the current working directory (or DFLOW_CWD if set).
-// Returns a populated Config struct or an error.
-func LoadConfig() (*Config, error) {
+// ConfigPath returns the path of the .dflow.yaml file in the current working directory
+// (or DFLOW_CWD if set).
+func ConfigPath() string {
 	dir := os.Getenv("DFLOW_CWD")
 	if dir == "" {
 		dir, _ = os.Getwd()
 	}
 
-	path := dir + string(os.PathSeparator) + ".dflow.yaml"
+	return dir + string(os.PathSeparator) + ".dflow.yaml"
+}
+
+// LoadConfig reads and parses the .dflow.yaml configuration file
+// from the current working directory (or DFLOW_CWD if set).
+// Returns a populated Config struct or an error.
+func LoadConfig() (*Config, error) {
+	path := ConfigPath()
 
 	data, err := os.ReadFile(path)
 	if err != nil {
@@ -77,9 +191,91 @@ func LoadConfig() (*Config, error) {
 		return nil, fmt.Errorf("error parsing .dflow.yaml: %v", err)
 	}
 
+	if err := ValidateConfig(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid .dflow.yaml: %w", err)
+	}
+
 	return &cfg, nil
 }
 
+// ValidateConfig checks that every branch prefix and base branch in the configuration
+// follows Git's reference format rules, using the same validator as branch creation,
+// and that the configured merge strategies, gates, hooks and patterns are well formed.
+func ValidateConfig(cfg *Config) error {
+	prefixes := []struct{ key, value string }{
+		{"branches.features", cfg.Branches.Features},
+		{"branches.releases", cfg.Branches.Releases},
+		{"branches.hotfixes", cfg.Branches.Hotfixes},
+		{"branches.bugfixes", cfg.Branches.Bugfixes},
+		{"branches.supports", cfg.Branches.Supports},
+		{"branches.backports", cfg.Branches.Backports},
+	}
+	for _, prefix := range prefixes {
+		if err := refformat.CheckBranchPrefix(prefix.value); err != nil {
+			return fmt.Errorf("%s: %w", prefix.key, err)
+		}
+	}
+
+	bases := []struct{ key, value string }{
+		{"branches.main", cfg.Branches.Main},
+		{"branches.develop", cfg.Branches.Develop},
+		{"branches.uat", cfg.Branches.Uat},
+		{"flow.feature_base", cfg.Flow.FeatureBase},
+		{"flow.feature_merge", cfg.Flow.FeatureMerge},
+		{"flow.release_base", cfg.Flow.ReleaseBase},
+		{"flow.hotfix_base", cfg.Flow.HotfixBase},
+		{"flow.bugfix_base", cfg.Flow.BugfixBase},
+	}
+	for _, base := range bases {
+		if base.value == "" {
+			continue
+		}
+		if err := refformat.CheckBranchName(base.value); err != nil {
+			return fmt.Errorf("%s: %w", base.key, err)
+		}
+	}
+
+	if err := validateMergeStrategies(cfg); err != nil {
+		return err
+	}
+
+	if err := validateVersioning(cfg); err != nil {
+		return err
+	}
+
+	if err := validatePromotion(cfg); err != nil {
+		return err
+	}
+
+	if cfg.Commits.Pattern != "" {
+		if _, err := regexp.Compile(cfg.Commits.Pattern); err != nil {
+			return fmt.Errorf("commits.pattern: %w", err)
+		}
+	}
+
+	for name := range cfg.Hooks {
+		if !IsLifecycleHook(name) {
+			return fmt.Errorf("hooks.%s: unknown hook (use %s)", name, strings.Join(LifecycleHooks, ", "))
+		}
+	}
+
+	for branchType, gates := range cfg.Gates {
+		for i, gate := range gates {
+			if strings.TrimSpace(gate.Run) == "" {
+				return fmt.Errorf("gates.%s[%d]: 'run' is required", branchType, i)
+			}
+			if gate.Timeout == "" {
+				continue
+			}
+			if _, err := time.ParseDuration(gate.Timeout); err != nil {
+				return fmt.Errorf("gates.%s[%d]: invalid timeout '%s'", branchType, i, gate.Timeout)
+			}
+		}
+	}
+
+	return nil
+}
+
 // SaveConfig writes the given Config struct to a .dflow.yaml file
 // in the current working directory (or DFLOW_CWD if set).
 //
@@ -93,12 +289,7 @@ func SaveConfig(cfg *Config) error {
 
 	finalContent := []byte(bannerToConfig + "\n" + string(yamlData))
 
-	dir := os.Getenv("DFLOW_CWD")
-	if dir == "" {
-		dir, _ = os.Getwd()
-	}
-
-	path := dir + string(os.PathSeparator) + ".dflow.yaml"
+	path := ConfigPath()
 
 	if err := os.WriteFile(path, finalContent, 0644); err != nil {
 		return fmt.Errorf("error writing .dflow.yaml: %v", err)
@@ -107,6 +298,45 @@ func SaveConfig(cfg *Config) error {
 	return nil
 }
 
+// GetWorktreeDir returns the directory where `dflow start --worktree` creates worktrees.
+//
+// Relative paths are resolved against the repository root. When `worktrees.dir` is not
+// set, worktrees are created next to the repository in `../<repo>-worktrees`.
+func GetWorktreeDir(cfg *Config, repoRoot string) string {
+	dir := cfg.Worktrees.Dir
+	if dir == "" {
+		dir = filepath.Join("..", filepath.Base(repoRoot)+"-worktrees")
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoRoot, dir)
+	}
+	return filepath.Clean(dir)
+}
+
+// GetTrashRetentionDays returns the number of days deleted branches are kept in the
+// dflow trash before `dflow trash purge` removes them.
+func GetTrashRetentionDays(cfg *Config) int {
+	if cfg.Trash.RetentionDays > 0 {
+		return cfg.Trash.RetentionDays
+	}
+	return DefaultTrashRetentionDays
+}
+
+// Strategies used by `dflow sync` to bring a branch up to date with its base.
+const (
+	SyncMerge  = "merge"
+	SyncRebase = "rebase"
+)
+
+// GetSyncStrategy returns how `dflow sync` integrates the base into branches of the
+// given type ("merge" or "rebase"), defaulting to merge.
+func GetSyncStrategy(cfg *Config, branchType string) string {
+	if strategy, ok := cfg.Workflow.SyncStrategy[branchType]; ok && strategy != "" {
+		return strategy
+	}
+	return SyncMerge
+}
+
 // GetMergeModeForBranch returns the merge mode ("auto" or "manual")
 // for the given branch, based on the .dflow.yaml configuration.
 //
@@ -118,3 +348,210 @@ func GetMergeModeForBranch(cfg *Config, branch string) string {
 	}
 	return cfg.Workflow.DefaultMergeMode
 }
+
+// Canonical branch types managed by dflow. Aliases accepted on the command line
+// (e.g. "feat" or "bug") are mapped to these values by ResolveBranchType.
+const (
+	TypeFeature = "feature"
+	TypeRelease = "release"
+	TypeHotfix  = "hotfix"
+	TypeBugfix  = "bugfix"
+
+	// TypeSupport branches maintain an older version line (support/1.x) and are never finished.
+	TypeSupport = "support"
+	// TypeBackport branches carry fixes cherry-picked onto another branch, backport/<target>/<name>.
+	TypeBackport = "backport"
+)
+
+// Prefixes of the support and backport branch types when they are not configured.
+const (
+	DefaultSupportPrefix  = "support/"
+	DefaultBackportPrefix = "backport/"
+)
+
+// BranchTypes lists every canonical branch type.
+var BranchTypes = []string{TypeFeature, TypeRelease, TypeHotfix, TypeBugfix, TypeSupport, TypeBackport}
+
+// ResolveBranchType maps a branch type or one of its aliases to its canonical name.
+//
+// It returns false if the value is not a known branch type.
+func ResolveBranchType(alias string) (string, bool) {
+	switch alias {
+	case "feat", "feature":
+		return TypeFeature, true
+	case "release":
+		return TypeRelease, true
+	case "fix", "hot", "hotfix":
+		return TypeHotfix, true
+	case "bug", "bugfix":
+		return TypeBugfix, true
+	case "support":
+		return TypeSupport, true
+	}
+	return "", false
+}
+
+// PrefixForType returns the configured branch prefix (e.g. "feature/") for a canonical branch type.
+func PrefixForType(cfg *Config, branchType string) string {
+	switch branchType {
+	case TypeFeature:
+		return cfg.Branches.Features
+	case TypeRelease:
+		return cfg.Branches.Releases
+	case TypeHotfix:
+		return cfg.Branches.Hotfixes
+	case TypeBugfix:
+		return cfg.Branches.Bugfixes
+	case TypeSupport:
+		if cfg.Branches.Supports != "" {
+			return cfg.Branches.Supports
+		}
+		return DefaultSupportPrefix
+	case TypeBackport:
+		if cfg.Branches.Backports != "" {
+			return cfg.Branches.Backports
+		}
+		return DefaultBackportPrefix
+	}
+	return ""
+}
+
+// BaseForType returns the configured base branch for a canonical branch type.
+func BaseForType(cfg *Config, branchType string) string {
+	switch branchType {
+	case TypeFeature:
+		return cfg.Flow.FeatureBase
+	case TypeRelease:
+		return cfg.Flow.ReleaseBase
+	case TypeHotfix:
+		return cfg.Flow.HotfixBase
+	case TypeBugfix:
+		return cfg.Flow.BugfixBase
+	}
+	return ""
+}
+
+// BranchTypeFor infers the branch type from the configured prefixes.
+//
+// When several prefixes match, the longest one wins. It returns an empty string
+// if the branch does not follow any configured prefix.
+func BranchTypeFor(cfg *Config, branch string) string {
+	branchType, longest := "", 0
+	for _, candidate := range BranchTypes {
+		prefix := PrefixForType(cfg, candidate)
+		if prefix != "" && strings.HasPrefix(branch, prefix) && len(prefix) > longest {
+			branchType, longest = candidate, len(prefix)
+		}
+	}
+	return branchType
+}
+
+// BaseBranches returns the long-lived branches declared in the configuration:
+// main, develop, UAT and every flow base, without duplicates or empty entries.
+//
+// These branches are treated as protected by destructive commands such as `dflow delete`.
+func BaseBranches(cfg *Config) []string {
+	candidates := []string{
+		cfg.Branches.Main,
+		cfg.Branches.Develop,
+		cfg.Branches.Uat,
+		cfg.Flow.FeatureBase,
+		cfg.Flow.FeatureMerge,
+		cfg.Flow.ReleaseBase,
+		cfg.Flow.HotfixBase,
+		cfg.Flow.BugfixBase,
+	}
+
+	seen := make(map[string]bool)
+	var branches []string
+	for _, branch := range candidates {
+		if branch == "" || seen[branch] {
+			continue
+		}
+		seen[branch] = true
+		branches = append(branches, branch)
+	}
+	return branches
+}
+
+// IsProtectedBranch reports whether the given branch is one of the configured base branches.
+func IsProtectedBranch(cfg *Config, branch string) bool {
+	for _, base := range BaseBranches(cfg) {
+		if base == branch {
+			return true
+		}
+	}
+	return false
+}
+
+// Lifecycle hooks run around dflow operations (see package lifecycle).
+const (
+	HookPreStart    = "pre-start"
+	HookPostStart   = "post-start"
+	HookPreFinish   = "pre-finish"
+	HookPostFinish  = "post-finish"
+	HookPreDelete   = "pre-delete"
+	HookPostDelete  = "post-delete"
+	HookPrePublish  = "pre-publish"
+	HookPostPublish = "post-publish"
+)
+
+// LifecycleHooks lists the valid hook names, in the order they are documented.
+var LifecycleHooks = []string{
+	HookPreStart, HookPostStart,
+	HookPreFinish, HookPostFinish,
+	HookPreDelete, HookPostDelete,
+	HookPrePublish, HookPostPublish,
+}
+
+// IsLifecycleHook reports whether name is one of LifecycleHooks.
+func IsLifecycleHook(name string) bool {
+	for _, hook := range LifecycleHooks {
+		if hook == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GetGates returns the gates configured for a branch type.
+func GetGates(cfg *Config, branchType string) []Gate {
+	return cfg.Gates[branchType]
+}
+
+// GetCommitPattern returns the regexp commit subjects must match.
+func GetCommitPattern(cfg *Config) string {
+	if cfg.Commits.Pattern != "" {
+		return cfg.Commits.Pattern
+	}
+	return DefaultCommitPattern
+}
+
+// GetTagPrefix returns the prefix of version tags (e.g. "v" in "v1.2.0").
+func GetTagPrefix(cfg *Config) string {
+	if cfg.Versioning.TagPrefix != "" {
+		return cfg.Versioning.TagPrefix
+	}
+	return DefaultTagPrefix
+}
+
+// GetTagPattern returns the regexp version tags must match: `versioning.tag_pattern`, or
+// the tag prefix followed by a version of the scheme (a semantic or calendar version with
+// an optional pre-release, or anything for the free scheme).
+//
+// The default is valid both as a Go regexp and as a POSIX extended regexp, so the
+// server-side hook can apply the same rule with grep.
+func GetTagPattern(cfg *Config) string {
+	if cfg.Versioning.TagPattern != "" {
+		return cfg.Versioning.TagPattern
+	}
+
+	prefix := "^" + regexp.QuoteMeta(GetTagPrefix(cfg))
+	switch GetVersionScheme(cfg) {
+	case SchemeCalver:
+		return prefix + GetCalverFormat(cfg).Pattern() + `(-[0-9A-Za-z.-]+)?$`
+	case SchemeFree:
+		return prefix + ".+$"
+	}
+	return prefix + `[0-9]+\.[0-9]+\.[0-9]+(-[0-9A-Za-z.-]+)?$`
+}
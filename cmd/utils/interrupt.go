@@ -3,14 +3,22 @@ package utils
 import (
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 )
 
+// interruptHandlers run before the program exits on an interrupt, see OnInterrupt.
+var (
+	interruptMu       sync.Mutex
+	interruptHandlers = make(map[int]func())
+	interruptNext     int
+)
+
 // HandleInterrupt installs a signal handler for OS interrupts (e.g. Ctrl+C or SIGTERM).
 //
-// When triggered, it prints a cancellation message and terminates the program
-// with exit code 1. This is intended to provide graceful shutdown behavior
-// during interactive command-line execution.
+// When triggered, it prints a cancellation message, runs the handlers registered with
+// OnInterrupt and terminates the program with exit code 1. This is intended to provide
+// graceful shutdown behavior during interactive command-line execution.
 func HandleInterrupt() {
 	sigs := make(chan os.Signal, 1)
 	signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
@@ -18,6 +26,27 @@ func HandleInterrupt() {
 	go func() {
 		<-sigs
 		println("\n🚫 Execution cancelled by user.")
+		interruptMu.Lock()
+		for _, handler := range interruptHandlers {
+			handler()
+		}
 		os.Exit(1)
 	}()
 }
+
+// OnInterrupt registers handler to run when an interrupt terminates the program, e.g. to
+// stop child processes that do not receive the signal themselves. It returns a function
+// that unregisters the handler.
+func OnInterrupt(handler func()) func() {
+	interruptMu.Lock()
+	defer interruptMu.Unlock()
+
+	id := interruptNext
+	interruptNext++
+	interruptHandlers[id] = handler
+	return func() {
+		interruptMu.Lock()
+		defer interruptMu.Unlock()
+		delete(interruptHandlers, id)
+	}
+}
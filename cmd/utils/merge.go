@@ -0,0 +1,164 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Merge strategies used when a flow branch is finished into its target.
+const (
+	MergeNoFF   = "no-ff"   // always create a merge commit
+	MergeSquash = "squash"  // squash the branch into a single commit
+	MergeRebase = "rebase"  // rebase the branch onto the target, then fast-forward
+	MergeFFOnly = "ff-only" // fast-forward only, fail if the histories diverged
+)
+
+// DefaultMergeStrategy is used when no strategy is configured for a branch type and target.
+const DefaultMergeStrategy = MergeNoFF
+
+// DefaultMergeMessage is the merge commit message used when a branch type has no template.
+const DefaultMergeMessage = "Merge {type} '{branch}' into {target}"
+
+// anyTarget is the key of a MergeStrategyRule that applies to every target.
+const anyTarget = "*"
+
+// MergeStrategies lists the valid values of `workflow.merge_strategy`.
+var MergeStrategies = []string{MergeNoFF, MergeSquash, MergeRebase, MergeFFOnly}
+
+// MergeStrategyRule maps merge targets to the strategy used for them, with "*" as the
+// fallback for any other target.
+//
+// In .dflow.yaml it can be written either as a single strategy applying to every target
+// or as a map keyed by target branch:
+//
+//	merge_strategy:
+//	    feature: squash
+//	    release:
+//	        main: no-ff
+//	        "*": ff-only
+type MergeStrategyRule map[string]string
+
+// UnmarshalYAML accepts both the scalar and the per-target form of a rule.
+func (r *MergeStrategyRule) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*r = MergeStrategyRule{anyTarget: node.Value}
+		return nil
+	}
+
+	var targets map[string]string
+	if err := node.Decode(&targets); err != nil {
+		return err
+	}
+	*r = targets
+	return nil
+}
+
+// MarshalYAML writes rules that only have a fallback in the short scalar form.
+func (r MergeStrategyRule) MarshalYAML() (interface{}, error) {
+	if strategy, ok := r[anyTarget]; ok && len(r) == 1 {
+		return strategy, nil
+	}
+	return map[string]string(r), nil
+}
+
+// MergeTargetForType returns the branch a finished branch of the given type merges into:
+// `flow.feature_merge` (or the feature base) for features, main for releases and
+// hotfixes, and the bugfix base for bugfixes. Support and backport branches have none
+// (backports merge into the target of their name, see BackportTarget).
+func MergeTargetForType(cfg *Config, branchType string) string {
+	switch branchType {
+	case TypeFeature:
+		if cfg.Flow.FeatureMerge != "" {
+			return cfg.Flow.FeatureMerge
+		}
+		return cfg.Flow.FeatureBase
+	case TypeRelease, TypeHotfix:
+		return cfg.Branches.Main
+	case TypeBugfix:
+		return cfg.Flow.BugfixBase
+	}
+	return ""
+}
+
+// GetMergeStrategy returns the strategy used to merge a branch of the given type into
+// target, looking up the exact target first and then the "*" fallback of the type.
+func GetMergeStrategy(cfg *Config, branchType string, target string) string {
+	rule := cfg.Workflow.MergeStrategy[branchType]
+	if strategy, ok := rule[target]; ok && strategy != "" {
+		return strategy
+	}
+	if strategy, ok := rule[anyTarget]; ok && strategy != "" {
+		return strategy
+	}
+	return DefaultMergeStrategy
+}
+
+// IsValidMergeStrategy reports whether strategy is one of MergeStrategies.
+func IsValidMergeStrategy(strategy string) bool {
+	for _, s := range MergeStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// PullRequestMergeMethod returns the pull request merge method equivalent to a merge
+// strategy ("merge", "squash" or "rebase"), as used by hosting platforms such as GitHub.
+//
+// Platforms have no fast-forward-only button, so ff-only maps to rebase, which keeps
+// the history linear without a merge commit.
+func PullRequestMergeMethod(strategy string) string {
+	switch strategy {
+	case MergeSquash:
+		return "squash"
+	case MergeRebase, MergeFFOnly:
+		return "rebase"
+	}
+	return "merge"
+}
+
+// MergeMessageData holds the values of the placeholders of a merge message template.
+type MergeMessageData struct {
+	Branch string
+	Type   string
+	Ticket string
+	Author string
+	Target string
+	Base   string
+}
+
+// RenderMergeMessage expands the merge message template configured for the branch type
+// (`workflow.merge_message.<type>`), or DefaultMergeMessage.
+//
+// Supported placeholders are {branch}, {type}, {ticket}, {author}, {target} and {base}.
+func RenderMergeMessage(cfg *Config, data MergeMessageData) string {
+	template := cfg.Workflow.MergeMessage[data.Type]
+	if template == "" {
+		template = DefaultMergeMessage
+	}
+
+	return strings.NewReplacer(
+		"{branch}", data.Branch,
+		"{type}", data.Type,
+		"{ticket}", data.Ticket,
+		"{author}", data.Author,
+		"{target}", data.Target,
+		"{base}", data.Base,
+	).Replace(template)
+}
+
+// validateMergeStrategies checks that every configured merge strategy is known.
+func validateMergeStrategies(cfg *Config) error {
+	for branchType, rule := range cfg.Workflow.MergeStrategy {
+		for target, strategy := range rule {
+			if !IsValidMergeStrategy(strategy) {
+				return fmt.Errorf("workflow.merge_strategy.%s.%s: unknown strategy '%s' (use %s)",
+					branchType, target, strategy, strings.Join(MergeStrategies, ", "))
+			}
+		}
+	}
+	return nil
+}
@@ -8,6 +8,12 @@ func Error(formattedMessage string, args ...interface{}) {
 	printWithIcon("❌", formattedMessage, args...)
 }
 
+// Errorf prints a message with a red cross (❌) prefix, like Error, but never takes its
+// last argument for an icon, so short values such as "5s" are formatted as given.
+func Errorf(format string, args ...interface{}) {
+	fmt.Printf("%-3s %s\n", "❌", fmt.Sprintf(format, args...))
+}
+
 // Info prints a message with an information icon (ℹ️) prefix.
 // Used to display general information about the process.
 func Info(formattedMessage string, args ...interface{}) {
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/pkg/slug"
+)
+
+// GetNamingRule returns the naming rule configured for a branch type, using
+// DefaultNamingTemplate when the type has no template.
+func GetNamingRule(cfg *Config, branchType string) NamingRule {
+	rule := cfg.Naming[branchType]
+	if rule.Template == "" {
+		rule.Template = DefaultNamingTemplate
+	}
+	return rule
+}
+
+// RequiresTicket reports whether the rule's template needs a ticket ID.
+func (r NamingRule) RequiresTicket() bool {
+	return strings.Contains(r.Template, "{ticket}")
+}
+
+// RenderBranchName expands the placeholders of a naming template.
+//
+// Unknown placeholders are left untouched so that validation can point them out.
+func RenderBranchName(template string, prefix string, branchType string, ticket string, slug string) string {
+	return strings.NewReplacer(
+		"{prefix}", prefix,
+		"{type}", branchType,
+		"{ticket}", ticket,
+		"{slug}", slug,
+	).Replace(template)
+}
+
+// Slugify normalizes free text into the {slug} part of a branch name using the
+// `slug` settings of .dflow.yaml (see package slug).
+func Slugify(cfg *Config, text string) string {
+	return slug.Make(text, slug.Options{
+		KeepUnicode: cfg.Slug.KeepUnicode,
+		MaxLength:   cfg.Slug.MaxLength,
+	})
+}
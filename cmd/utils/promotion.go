@@ -0,0 +1,131 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/pkg/refformat"
+)
+
+// Promotion defaults, see `promotion`.
+const (
+	DefaultPromotionMessage   = "Merge {from} into {to}"
+	DefaultPromotionTagFormat = "{to}-{date}.{n}"
+)
+
+// PromotionStrategies lists the merge strategies of `promotion.strategy`. Squash and
+// rebase are left out: they rewrite history, so the next promotion would bring the same
+// changes again.
+var PromotionStrategies = []string{MergeNoFF, MergeFFOnly}
+
+// GetPromotionChain returns the environment branches in promotion order: `promotion.chain`,
+// or develop, UAT and main as configured under `branches`.
+func GetPromotionChain(cfg *Config) []string {
+	if len(cfg.Promotion.Chain) > 0 {
+		return cfg.Promotion.Chain
+	}
+
+	var chain []string
+	for _, branch := range []string{cfg.Branches.Develop, cfg.Branches.Uat, cfg.Branches.Main} {
+		if branch != "" && (len(chain) == 0 || chain[len(chain)-1] != branch) {
+			chain = append(chain, branch)
+		}
+	}
+	return chain
+}
+
+// NextEnvironment returns the branch promotions from branch go into, or an empty string
+// when branch is the last one of the chain or not part of it.
+func NextEnvironment(cfg *Config, branch string) string {
+	chain := GetPromotionChain(cfg)
+	for i := 0; i+1 < len(chain); i++ {
+		if chain[i] == branch {
+			return chain[i+1]
+		}
+	}
+	return ""
+}
+
+// IsPromotion reports whether from may be promoted into to: to must follow from in the chain.
+func IsPromotion(cfg *Config, from string, to string) bool {
+	return to != "" && NextEnvironment(cfg, from) == to
+}
+
+// GetPromotionStrategy returns the merge strategy of promotions.
+func GetPromotionStrategy(cfg *Config) string {
+	if cfg.Promotion.Strategy != "" {
+		return cfg.Promotion.Strategy
+	}
+	return MergeNoFF
+}
+
+// IsValidPromotionStrategy reports whether strategy is one of PromotionStrategies.
+func IsValidPromotionStrategy(strategy string) bool {
+	for _, s := range PromotionStrategies {
+		if s == strategy {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderPromotionMessage expands the merge message template of promotions, with the
+// placeholders {from} and {to}.
+func RenderPromotionMessage(cfg *Config, from string, to string) string {
+	template := cfg.Promotion.Message
+	if template == "" {
+		template = DefaultPromotionMessage
+	}
+	return strings.NewReplacer("{from}", from, "{to}", to).Replace(template)
+}
+
+// GetPromotionTagFormat returns the template of promotion tags.
+func GetPromotionTagFormat(cfg *Config) string {
+	if cfg.Promotion.TagFormat != "" {
+		return cfg.Promotion.TagFormat
+	}
+	return DefaultPromotionTagFormat
+}
+
+// RenderPromotionTag returns the name of the n-th promotion tag of the day, e.g.
+// "uat-2026-10-16.1". The template accepts {from}, {to}, {date} (YYYY-MM-DD) and {n}.
+func RenderPromotionTag(cfg *Config, from string, to string, date time.Time, n int) string {
+	return strings.NewReplacer(
+		"{from}", from,
+		"{to}", to,
+		"{date}", date.Format("2006-01-02"),
+		"{n}", fmt.Sprint(n),
+	).Replace(GetPromotionTagFormat(cfg))
+}
+
+// validatePromotion checks that the chain lists distinct base branches and that the
+// strategy and tag format are usable.
+func validatePromotion(cfg *Config) error {
+	seen := make(map[string]bool)
+	for i, branch := range cfg.Promotion.Chain {
+		if !IsProtectedBranch(cfg, branch) {
+			return fmt.Errorf("promotion.chain[%d]: '%s' is not a base branch (%s)", i, branch, strings.Join(BaseBranches(cfg), ", "))
+		}
+		if seen[branch] {
+			return fmt.Errorf("promotion.chain[%d]: '%s' is listed twice", i, branch)
+		}
+		seen[branch] = true
+	}
+	if len(cfg.Promotion.Chain) == 1 {
+		return fmt.Errorf("promotion.chain: at least two branches are required")
+	}
+
+	if !IsValidPromotionStrategy(GetPromotionStrategy(cfg)) {
+		return fmt.Errorf("promotion.strategy: unknown strategy '%s' (use %s)", cfg.Promotion.Strategy, strings.Join(PromotionStrategies, ", "))
+	}
+
+	format := GetPromotionTagFormat(cfg)
+	if !strings.Contains(format, "{n}") {
+		return fmt.Errorf("promotion.tag_format: '%s' must contain {n}", format)
+	}
+	if err := refformat.CheckBranchName(RenderPromotionTag(cfg, "develop", "uat", time.Now(), 1)); err != nil {
+		return fmt.Errorf("promotion.tag_format: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"strings"
+)
+
+// SupportBranch returns the support branch of a version line, e.g. support/1.x for "1.x".
+// Names that already carry the support prefix are returned as they are.
+func SupportBranch(cfg *Config, line string) string {
+	prefix := PrefixForType(cfg, TypeSupport)
+	if strings.HasPrefix(line, prefix) {
+		return line
+	}
+	return prefix + line
+}
+
+// InSupportLine reports whether version belongs to the line of a support branch: each
+// part of the line must equal the same part of the version, except "x" and "*" which
+// match anything (1.x matches 1.4.3, 1.4.x matches 1.4.3 but not 1.5.0). Support branches
+// that are not named after a version line, such as support/legacy, accept every version.
+func InSupportLine(cfg *Config, branch string, version string) bool {
+	line, ok := supportLine(cfg, branch)
+	if !ok {
+		return true
+	}
+
+	parts := strings.Split(strings.TrimPrefix(version, GetTagPrefix(cfg)), ".")
+	if len(parts) < len(line) {
+		return false
+	}
+	for i, part := range line {
+		if !isLineWildcard(part) && part != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// HasVersionLine reports whether a support branch is named after a version line with
+// wildcards, such as support/1.x.
+func HasVersionLine(cfg *Config, branch string) bool {
+	_, ok := supportLine(cfg, branch)
+	return ok
+}
+
+// supportLine splits the name of a support branch into its parts, and reports whether one
+// of them is a wildcard.
+func supportLine(cfg *Config, branch string) ([]string, bool) {
+	line := strings.Split(strings.TrimPrefix(branch, PrefixForType(cfg, TypeSupport)), ".")
+	for _, part := range line {
+		if isLineWildcard(part) {
+			return line, true
+		}
+	}
+	return line, false
+}
+
+// isLineWildcard reports whether part of a version line matches any version part.
+func isLineWildcard(part string) bool {
+	return part == "x" || part == "*"
+}
+
+// BackportBranch returns the branch carrying a backport of name onto target, e.g.
+// backport/support/1.x/fix-login.
+func BackportBranch(cfg *Config, target string, name string) string {
+	return PrefixForType(cfg, TypeBackport) + target + "/" + name
+}
+
+// BackportTarget returns the target encoded in the name of a backport branch, or an empty
+// string when branch is not a backport branch.
+func BackportTarget(cfg *Config, branch string) string {
+	prefix := PrefixForType(cfg, TypeBackport)
+	if !strings.HasPrefix(branch, prefix) {
+		return ""
+	}
+	rest := strings.TrimPrefix(branch, prefix)
+	i := strings.LastIndex(rest, "/")
+	if i <= 0 {
+		return ""
+	}
+	return rest[:i]
+}
@@ -20,12 +20,21 @@ const banner = `
                    dflow %s - Git branching made simple
 `
 
+// AnnotationNoBanner is a Cobra command annotation that suppresses the banner, for
+// commands whose output is consumed by other programs (git hooks, plugins).
+const AnnotationNoBanner = "dflow.no-banner"
+
 // SetVersion overrides the internal version string.
 // Typically used by main.go via build-time injection.
 func SetVersion(v string) {
 	version = v
 }
 
+// Version returns the current CLI version.
+func Version() string {
+	return version
+}
+
 // PrintBanner prints the dflow ASCII banner with the current version.
 // Useful for CLI startup or version subcommand.
 func PrintBanner() {
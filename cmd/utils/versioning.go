@@ -0,0 +1,232 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/pkg/calver"
+	"github.com/yepizrene-devoost/dflow/pkg/semver"
+)
+
+// Versioning schemes of `versioning.scheme`.
+const (
+	SchemeSemver = "semver" // MAJOR.MINOR.PATCH
+	SchemeCalver = "calver" // calendar versions following `versioning.calver_format`
+	SchemeFree   = "free"   // any release name
+)
+
+// VersionSchemes lists the valid values of `versioning.scheme`.
+var VersionSchemes = []string{SchemeSemver, SchemeCalver, SchemeFree}
+
+// DefaultCalverFormat is used when the calver scheme has no `versioning.calver_format`.
+const DefaultCalverFormat = "YYYY.MM.MICRO"
+
+// Keys of `versioning.rules` that are not branch types.
+const (
+	VersionRuleMain    = "main"    // the main branch
+	VersionRuleDevelop = "develop" // the develop branch
+	VersionRuleDefault = "default" // any other branch (e.g. uat)
+)
+
+// Release candidate defaults, see `versioning.candidates`.
+const (
+	DefaultCandidateLabel  = "rc"
+	DefaultCandidateFormat = "{label}.{n}"
+)
+
+// DefaultBumpMessage is the commit message of version file bumps when
+// `versioning.bump_message` is empty.
+const DefaultBumpMessage = "chore(release): bump version to {version}"
+
+// VersionFile is a file holding the project version, rewritten when a release starts or a
+// hotfix finishes. At most one locator is set; without one the whole file is the version.
+type VersionFile struct {
+	Path  string `yaml:"path"`  // relative to the repository root, e.g. "package.json"
+	Regex string `yaml:"regex"` // regexp whose first capture group (or whole match) is the version
+	JSON  string `yaml:"json"`  // dot-separated path of a JSON string, e.g. "version"
+	YAML  string `yaml:"yaml"`  // dot-separated path of a YAML scalar, e.g. "image.tag"
+}
+
+// Locator returns a description of how the version is found in the file, for messages.
+func (f VersionFile) Locator() string {
+	switch {
+	case f.Regex != "":
+		return fmt.Sprintf("regex '%s'", f.Regex)
+	case f.JSON != "":
+		return fmt.Sprintf("json path '%s'", f.JSON)
+	case f.YAML != "":
+		return fmt.Sprintf("yaml path '%s'", f.YAML)
+	}
+	return "whole file"
+}
+
+// VersionRule controls how `dflow version compute` derives a version on a branch.
+type VersionRule struct {
+	Increment string `yaml:"increment"` // part bumped from the latest tag: major, minor, patch or none
+	Label     string `yaml:"label"`     // pre-release label template, e.g. "alpha.{branch}" (empty = release version)
+}
+
+// DefaultVersionRules are used for the keys missing from `versioning.rules`.
+var DefaultVersionRules = map[string]VersionRule{
+	VersionRuleMain:    {Increment: semver.Patch},
+	VersionRuleDevelop: {Increment: semver.Minor, Label: "alpha"},
+	TypeFeature:        {Increment: semver.Minor, Label: "alpha.{branch}"},
+	TypeBugfix:         {Increment: semver.Minor, Label: "alpha.{branch}"},
+	TypeRelease:        {Increment: semver.Minor, Label: "rc"},
+	TypeHotfix:         {Increment: semver.Patch, Label: "beta"},
+	TypeSupport:        {Increment: semver.Patch},
+	TypeBackport:       {Increment: semver.Patch, Label: "alpha.{branch}"},
+	VersionRuleDefault: {Increment: semver.Minor, Label: "{branch}"},
+}
+
+// VersionRuleKey returns the `versioning.rules` key that applies to branch.
+func VersionRuleKey(cfg *Config, branch string) string {
+	switch {
+	case branch != "" && branch == cfg.Branches.Main:
+		return VersionRuleMain
+	case branch != "" && branch == cfg.Branches.Develop:
+		return VersionRuleDevelop
+	}
+	if branchType := BranchTypeFor(cfg, branch); branchType != "" {
+		return branchType
+	}
+	return VersionRuleDefault
+}
+
+// GetVersionRule returns the version rule for key, filling the fields left empty in
+// `versioning.rules` from DefaultVersionRules.
+func GetVersionRule(cfg *Config, key string) VersionRule {
+	rule := DefaultVersionRules[key]
+	if configured, ok := cfg.Versioning.Rules[key]; ok {
+		if configured.Increment != "" {
+			rule.Increment = configured.Increment
+		}
+		if configured.Label != "" {
+			rule.Label = configured.Label
+		}
+	}
+	return rule
+}
+
+// GetVersionScheme returns the versioning scheme, semver when unset.
+func GetVersionScheme(cfg *Config) string {
+	if cfg.Versioning.Scheme != "" {
+		return cfg.Versioning.Scheme
+	}
+	return SchemeSemver
+}
+
+// GetCalverFormat returns the parsed calendar version format. The format is checked when
+// the configuration is loaded, so DefaultCalverFormat is only used for invalid ones.
+func GetCalverFormat(cfg *Config) calver.Format {
+	if cfg.Versioning.CalverFormat != "" {
+		if format, err := calver.ParseFormat(cfg.Versioning.CalverFormat); err == nil {
+			return format
+		}
+	}
+	format, _ := calver.ParseFormat(DefaultCalverFormat)
+	return format
+}
+
+// GetCandidateLabel returns the pre-release label of release candidates.
+func GetCandidateLabel(cfg *Config) string {
+	if cfg.Versioning.Candidates.Label != "" {
+		return cfg.Versioning.Candidates.Label
+	}
+	return DefaultCandidateLabel
+}
+
+// GetCandidateFormat returns the numbering scheme of release candidates, a template with
+// {label} and {n} placeholders such as "{label}.{n}" (rc.1) or "{label}{n}" (rc1).
+func GetCandidateFormat(cfg *Config) string {
+	if cfg.Versioning.Candidates.Format != "" {
+		return cfg.Versioning.Candidates.Format
+	}
+	return DefaultCandidateFormat
+}
+
+// GetCandidateStart returns the number of the first release candidate.
+func GetCandidateStart(cfg *Config) int {
+	if cfg.Versioning.Candidates.Start > 0 {
+		return cfg.Versioning.Candidates.Start
+	}
+	return 1
+}
+
+// RenderCandidate returns the pre-release of the release candidate number n, e.g. "rc.2".
+func RenderCandidate(cfg *Config, n int) string {
+	return strings.NewReplacer("{label}", GetCandidateLabel(cfg), "{n}", fmt.Sprint(n)).Replace(GetCandidateFormat(cfg))
+}
+
+// GetBumpMessage returns the commit message of a version file bump, rendering the
+// placeholders {version}, {branch} and {type}.
+func GetBumpMessage(cfg *Config, version string, branch string, branchType string) string {
+	message := cfg.Versioning.BumpMessage
+	if message == "" {
+		message = DefaultBumpMessage
+	}
+	return strings.NewReplacer("{version}", version, "{branch}", branch, "{type}", branchType).Replace(message)
+}
+
+// validateVersioning checks the scheme, the tag pattern, the keys and increments of `versioning.rules`,
+// the release candidate scheme and the version files.
+func validateVersioning(cfg *Config) error {
+	validScheme := false
+	for _, scheme := range VersionSchemes {
+		validScheme = validScheme || GetVersionScheme(cfg) == scheme
+	}
+	if !validScheme {
+		return fmt.Errorf("versioning.scheme: unknown scheme '%s' (use %s)", cfg.Versioning.Scheme, strings.Join(VersionSchemes, ", "))
+	}
+
+	if cfg.Versioning.CalverFormat != "" {
+		if _, err := calver.ParseFormat(cfg.Versioning.CalverFormat); err != nil {
+			return fmt.Errorf("versioning.calver_format: %w", err)
+		}
+	}
+
+	if cfg.Versioning.TagPattern != "" {
+		if _, err := regexp.Compile(cfg.Versioning.TagPattern); err != nil {
+			return fmt.Errorf("versioning.tag_pattern: %w", err)
+		}
+	}
+
+	for key, rule := range cfg.Versioning.Rules {
+		if _, ok := DefaultVersionRules[key]; !ok {
+			return fmt.Errorf("versioning.rules.%s: unknown key (use main, develop, feature, bugfix, release, hotfix, support, backport or default)", key)
+		}
+		if rule.Increment != "" && !semver.IsValidIncrement(rule.Increment) {
+			return fmt.Errorf("versioning.rules.%s: unknown increment '%s' (use %s)",
+				key, rule.Increment, strings.Join(semver.Increments, ", "))
+		}
+	}
+
+	if !strings.Contains(GetCandidateFormat(cfg), "{n}") {
+		return fmt.Errorf("versioning.candidates.format: '%s' must contain {n}", GetCandidateFormat(cfg))
+	}
+	if _, err := semver.Parse("1.0.0-" + RenderCandidate(cfg, GetCandidateStart(cfg))); err != nil {
+		return fmt.Errorf("versioning.candidates: '%s' is not a valid pre-release", RenderCandidate(cfg, GetCandidateStart(cfg)))
+	}
+
+	for i, file := range cfg.VersionFiles {
+		if file.Path == "" {
+			return fmt.Errorf("version_files[%d]: path is required", i)
+		}
+		locators := 0
+		for _, locator := range []string{file.Regex, file.JSON, file.YAML} {
+			if locator != "" {
+				locators++
+			}
+		}
+		if locators > 1 {
+			return fmt.Errorf("version_files[%d] (%s): set only one of regex, json and yaml", i, file.Path)
+		}
+		if file.Regex != "" {
+			if _, err := regexp.Compile(file.Regex); err != nil {
+				return fmt.Errorf("version_files[%d] (%s): %w", i, file.Path, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,145 @@
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+)
+
+// Candidate is a release candidate tag, e.g. v1.3.0-rc.2.
+type Candidate struct {
+	gitutils.Tag
+	Version    string // version of the tag without prefix, e.g. 1.3.0-rc.2
+	PreRelease string // candidate part of the version, e.g. rc.2
+	Number     int    // candidate number, 2 for rc.2
+}
+
+// Release groups the tags and branch of a release line.
+type Release struct {
+	Version    string        // version of the release in the configured scheme, e.g. 1.3.0
+	Branch     string        // local release branch, if any
+	Final      *gitutils.Tag // final tag, once the release is promoted
+	Candidates []Candidate   // release candidates, by number
+}
+
+// ReleaseVersion returns the version released by a release branch: the version in its
+// name (release/1.3.0), or, with the semver scheme, the next version computed for it.
+func ReleaseVersion(cfg *utils.Config, branch string) (string, error) {
+	if version, ok := versionFromBranch(cfg, branch, utils.TypeRelease); ok {
+		return version, nil
+	}
+	if utils.GetVersionScheme(cfg) != utils.SchemeSemver {
+		name := strings.TrimPrefix(branch, utils.PrefixForType(cfg, utils.TypeRelease))
+		return "", ValidateReleaseName(cfg, name)
+	}
+
+	result, err := Compute(cfg, branch, "refs/heads/"+branch)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%d.%d.%d", result.Major, result.Minor, result.Patch), nil
+}
+
+// Candidates returns the release candidates of version, ordered by number.
+func Candidates(cfg *utils.Config, version string) []Candidate {
+	for _, release := range Releases(cfg) {
+		if release.Version == version {
+			return release.Candidates
+		}
+	}
+	return nil
+}
+
+// NextCandidate returns the tag of the next release candidate of version and its number.
+func NextCandidate(cfg *utils.Config, version string) (string, int) {
+	number := utils.GetCandidateStart(cfg)
+	if candidates := Candidates(cfg, version); len(candidates) > 0 {
+		number = candidates[len(candidates)-1].Number + 1
+	}
+	return utils.GetTagPrefix(cfg) + version + "-" + utils.RenderCandidate(cfg, number), number
+}
+
+// Releases returns the release lines found in the version tags and the local release
+// branches, newest version first according to the versioning scheme.
+func Releases(cfg *utils.Config) []Release {
+	prefix := utils.GetTagPrefix(cfg)
+	candidate := candidatePattern(cfg)
+
+	lines := make(map[string]*Release)
+	line := func(version string) *Release {
+		if lines[version] == nil {
+			lines[version] = &Release{Version: version}
+		}
+		return lines[version]
+	}
+
+	for _, tag := range gitutils.ListTags() {
+		if !strings.HasPrefix(tag.Name, prefix) {
+			continue
+		}
+		version := strings.TrimPrefix(tag.Name, prefix)
+
+		if release, preRelease, number, ok := splitCandidate(cfg, candidate, version); ok {
+			line(release).Candidates = append(line(release).Candidates,
+				Candidate{Tag: tag, Version: version, PreRelease: preRelease, Number: number})
+			continue
+		}
+		if isRelease(cfg, version) {
+			final := tag
+			line(version).Final = &final
+		}
+	}
+
+	releasePrefix := utils.PrefixForType(cfg, utils.TypeRelease)
+	for _, branch := range gitutils.GetLocalBranches() {
+		if releasePrefix == "" || !strings.HasPrefix(branch, releasePrefix) {
+			continue
+		}
+		if version, ok := versionFromBranch(cfg, branch, utils.TypeRelease); ok {
+			line(version).Branch = branch
+		}
+	}
+
+	releases := make([]Release, 0, len(lines))
+	for _, release := range lines {
+		sort.Slice(release.Candidates, func(i, j int) bool {
+			return release.Candidates[i].Number < release.Candidates[j].Number
+		})
+		releases = append(releases, *release)
+	}
+	sort.Slice(releases, func(i, j int) bool {
+		return compareVersions(cfg, releases[i].Version, releases[j].Version) > 0
+	})
+	return releases
+}
+
+// splitCandidate splits the version of a candidate tag, e.g. 2026.10.1-rc.2, into the
+// release version of the scheme and the candidate part matching pattern, with its number.
+// Every '-' is tried, since free-form release names and labels may contain one.
+func splitCandidate(cfg *utils.Config, pattern *regexp.Regexp, version string) (string, string, int, bool) {
+	for i := 0; i < len(version); i++ {
+		if version[i] != '-' {
+			continue
+		}
+		release, preRelease := version[:i], version[i+1:]
+		if match := pattern.FindStringSubmatch(preRelease); match != nil && isRelease(cfg, release) {
+			number, _ := strconv.Atoi(match[1])
+			return release, preRelease, number, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// candidatePattern matches the pre-release of a release candidate, capturing its number.
+func candidatePattern(cfg *utils.Config) *regexp.Regexp {
+	parts := strings.Split(utils.GetCandidateFormat(cfg), "{n}")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(strings.ReplaceAll(part, "{label}", utils.GetCandidateLabel(cfg)))
+	}
+	return regexp.MustCompile(fmt.Sprintf("^%s$", strings.Join(parts, "(0|[1-9][0-9]*)")))
+}
@@ -0,0 +1,276 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// span is the byte range of a version string inside a file, quotes excluded.
+type span struct {
+	start, end int
+}
+
+// BumpFiles rewrites the version files of the configuration, relative to root, to version
+// and returns the paths that changed. Every locator must match and read back the new
+// version afterwards, otherwise nothing is written.
+func BumpFiles(cfg *utils.Config, root string, version string) ([]string, error) {
+	updated := make(map[string][]byte)
+	var order []string
+
+	for _, file := range cfg.VersionFiles {
+		path := filepath.Join(root, file.Path)
+		content, ok := updated[file.Path]
+		if !ok {
+			var err error
+			if content, err = os.ReadFile(path); err != nil {
+				return nil, fmt.Errorf("version file %s: %w", file.Path, err)
+			}
+			order = append(order, file.Path)
+		}
+
+		bumped, err := BumpContent(file, content, version)
+		if err != nil {
+			return nil, fmt.Errorf("version file %s: %w", file.Path, err)
+		}
+		updated[file.Path] = bumped
+	}
+
+	var changed []string
+	for _, name := range order {
+		path := filepath.Join(root, name)
+		current, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("version file %s: %w", name, err)
+		}
+		if bytes.Equal(current, updated[name]) {
+			continue
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("version file %s: %w", name, err)
+		}
+		if err := os.WriteFile(path, updated[name], info.Mode().Perm()); err != nil {
+			return nil, fmt.Errorf("version file %s: %w", name, err)
+		}
+		changed = append(changed, name)
+	}
+	return changed, nil
+}
+
+// BumpContent returns content with the version located by file replaced by version. It
+// fails when the locator matches nothing or does not read back version after the rewrite.
+func BumpContent(file utils.VersionFile, content []byte, version string) ([]byte, error) {
+	spans, err := locate(file, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// replace from the end so earlier offsets stay valid
+	sort.Slice(spans, func(i, j int) bool { return spans[i].start > spans[j].start })
+	bumped := append([]byte(nil), content...)
+	for _, s := range spans {
+		bumped = append(bumped[:s.start], append([]byte(version), bumped[s.end:]...)...)
+	}
+
+	check, err := locate(file, bumped)
+	if err != nil {
+		return nil, fmt.Errorf("rewrite could not be verified: %w", err)
+	}
+	for _, s := range check {
+		if found := string(bumped[s.start:s.end]); found != version {
+			return nil, fmt.Errorf("rewrite could not be verified: %s reads '%s' instead of '%s'", file.Locator(), found, version)
+		}
+	}
+	return bumped, nil
+}
+
+// locate returns the spans of the version in content according to the locator of file.
+func locate(file utils.VersionFile, content []byte) ([]span, error) {
+	switch {
+	case file.Regex != "":
+		return locateRegex(file.Regex, content)
+	case file.JSON != "":
+		s, err := locateJSON(file.JSON, content)
+		return []span{s}, err
+	case file.YAML != "":
+		s, err := locateYAML(file.YAML, content)
+		return []span{s}, err
+	}
+
+	trimmed := bytes.TrimRight(content, " \t\r\n")
+	if len(bytes.TrimSpace(trimmed)) == 0 {
+		return nil, errors.New("the file is empty")
+	}
+	start := len(trimmed) - len(bytes.TrimLeft(trimmed, " \t\r\n"))
+	return []span{{start, len(trimmed)}}, nil
+}
+
+// locateRegex returns the first capture group (the whole match without groups) of every
+// match of pattern.
+func locateRegex(pattern string, content []byte) ([]span, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	group := 0
+	if re.NumSubexp() > 0 {
+		group = 1
+	}
+
+	var spans []span
+	for _, match := range re.FindAllSubmatchIndex(content, -1) {
+		if start, end := match[2*group], match[2*group+1]; start >= 0 {
+			spans = append(spans, span{start, end})
+		}
+	}
+	if len(spans) == 0 {
+		return nil, fmt.Errorf("regex '%s' matches nothing", pattern)
+	}
+	return spans, nil
+}
+
+// locateJSON returns the span of the string at the dot-separated path of a JSON document.
+func locateJSON(path string, content []byte) (span, error) {
+	if !json.Valid(content) {
+		return span{}, errors.New("the file is not valid JSON")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(content))
+	keys := strings.Split(path, ".")
+	for depth := 0; ; depth++ {
+		before := int(dec.InputOffset())
+		token, _ := dec.Token()
+
+		if depth == len(keys) {
+			value, ok := token.(string)
+			if !ok {
+				return span{}, fmt.Errorf("json path '%s' is not a string", path)
+			}
+			after := int(dec.InputOffset())
+			start := before + bytes.IndexByte(content[before:after], '"') + 1
+			if string(content[start:after-1]) != value {
+				return span{}, fmt.Errorf("json path '%s' contains escapes and cannot be rewritten in place", path)
+			}
+			return span{start, after - 1}, nil
+		}
+
+		found := false
+		switch token {
+		case json.Delim('{'):
+			for !found && dec.More() {
+				key, _ := dec.Token()
+				if found = key == keys[depth]; !found {
+					skipJSON(dec)
+				}
+			}
+		case json.Delim('['):
+			index, err := strconv.Atoi(keys[depth])
+			for i := 0; err == nil && !found && dec.More(); i++ {
+				if found = i == index; !found {
+					skipJSON(dec)
+				}
+			}
+		}
+		if !found {
+			return span{}, fmt.Errorf("json path '%s' matches nothing", path)
+		}
+	}
+}
+
+// skipJSON consumes the next value of dec.
+func skipJSON(dec *json.Decoder) {
+	depth := 0
+	for {
+		token, err := dec.Token()
+		if err != nil {
+			return
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return
+		}
+	}
+}
+
+// locateYAML returns the span of the scalar at the dot-separated path of a YAML document.
+func locateYAML(path string, content []byte) (span, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return span{}, fmt.Errorf("the file is not valid YAML: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return span{}, errors.New("the file is empty")
+	}
+
+	node := doc.Content[0]
+	for _, key := range strings.Split(path, ".") {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					next = node.Content[i+1]
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+			}
+		}
+		if next == nil {
+			return span{}, fmt.Errorf("yaml path '%s' matches nothing", path)
+		}
+		node = next
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return span{}, fmt.Errorf("yaml path '%s' is not a single-line scalar", path)
+	}
+
+	start := lineOffset(content, node.Line, node.Column)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		start++
+	}
+	end := start + len(node.Value)
+	if start < 0 || end > len(content) || string(content[start:end]) != node.Value {
+		return span{}, fmt.Errorf("yaml path '%s' contains escapes and cannot be rewritten in place", path)
+	}
+	return span{start, end}, nil
+}
+
+// lineOffset returns the byte offset of a 1-based line and rune column in content, -1 when
+// out of range.
+func lineOffset(content []byte, line int, column int) int {
+	offset := 0
+	for ; line > 1; line-- {
+		i := bytes.IndexByte(content[offset:], '\n')
+		if i < 0 {
+			return -1
+		}
+		offset += i + 1
+	}
+	for ; column > 1 && offset < len(content); column-- {
+		_, size := utf8.DecodeRune(content[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,215 @@
+package versioning
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/semver"
+)
+
+// ValidateReleaseName checks that the name of a release (without branch prefix) is a
+// version of the configured scheme. A leading tag prefix is accepted (v1.3.0).
+func ValidateReleaseName(cfg *utils.Config, name string) error {
+	version := strings.TrimPrefix(name, utils.GetTagPrefix(cfg))
+	if isRelease(cfg, version) {
+		return nil
+	}
+
+	switch utils.GetVersionScheme(cfg) {
+	case utils.SchemeCalver:
+		return fmt.Errorf("'%s' does not follow the calver format '%s'", name, calverFormatText(cfg))
+	default:
+		return fmt.Errorf("'%s' is not a semantic version (MAJOR.MINOR.PATCH)", name)
+	}
+}
+
+// ReleaseTag returns the version tag of a finished release or hotfix branch, e.g. v1.3.0
+// for release/1.3.0. It returns false when the branch is not named after a version.
+func ReleaseTag(cfg *utils.Config, branch string, branchType string) (string, bool) {
+	name := strings.TrimPrefix(branch, utils.PrefixForType(cfg, branchType))
+	version := strings.TrimPrefix(name, utils.GetTagPrefix(cfg))
+
+	// with the free scheme any release name is a version, but hotfix names usually are not
+	if utils.GetVersionScheme(cfg) == utils.SchemeFree && branchType != utils.TypeRelease {
+		return "", false
+	}
+	if version == "" || !isRelease(cfg, version) {
+		return "", false
+	}
+	return utils.GetTagPrefix(cfg) + version, true
+}
+
+// NextRelease returns the name of the next release: the next minor version for semver, or
+// the calendar version of now for calver. Released versions and the versions of local
+// release branches are taken into account.
+func NextRelease(cfg *utils.Config, now time.Time) (string, error) {
+	existing := releasedVersions(cfg, "")
+	releasePrefix := utils.PrefixForType(cfg, utils.TypeRelease)
+	for _, branch := range gitutils.GetLocalBranches() {
+		if releasePrefix == "" || !strings.HasPrefix(branch, releasePrefix) {
+			continue
+		}
+		if version := strings.TrimPrefix(strings.TrimPrefix(branch, releasePrefix), utils.GetTagPrefix(cfg)); isRelease(cfg, version) {
+			existing = append(existing, version)
+		}
+	}
+
+	switch utils.GetVersionScheme(cfg) {
+	case utils.SchemeCalver:
+		return utils.GetCalverFormat(cfg).Next(now, existing)
+	case utils.SchemeFree:
+		return "", fmt.Errorf("release names cannot be generated with the free versioning scheme")
+	}
+
+	latest, _ := latestVersion(cfg, existing)
+	next, err := mustSemver(latest).Bump(semver.Minor)
+	return next.String(), err
+}
+
+// NextHotfix returns the name of the next hotfix started from base (the hotfix base, or a
+// support branch): the version following the latest release reachable from it (next patch
+// for semver, next MICRO for calver).
+func NextHotfix(cfg *utils.Config, base string) (string, error) {
+	ref := base
+	if !gitutils.LocalBranchExists(base) && gitutils.RefExists("refs/remotes/origin/"+base) {
+		ref = "origin/" + base
+	}
+
+	latest, ok := latestVersion(cfg, releasedVersions(cfg, ref))
+	bump := func(version string) (string, error) {
+		next, err := mustSemver(version).Bump(semver.Patch)
+		return next.String(), err
+	}
+
+	switch utils.GetVersionScheme(cfg) {
+	case utils.SchemeCalver:
+		if !ok {
+			return "", fmt.Errorf("no release tag found on '%s' to derive the hotfix version from", base)
+		}
+		bump = utils.GetCalverFormat(cfg).BumpMicro
+	case utils.SchemeFree:
+		return "", fmt.Errorf("hotfix names cannot be generated with the free versioning scheme")
+	}
+
+	// skip versions already taken by a tag or an ongoing release or hotfix
+	next, err := bump(latest)
+	for err == nil && versionTaken(cfg, next) {
+		next, err = bump(next)
+	}
+	return next, err
+}
+
+// SupportStartTag returns the latest release tag in the version line of a support branch,
+// e.g. v1.4.2 for support/1.x, to start the branch from.
+func SupportStartTag(cfg *utils.Config, branch string) (string, error) {
+	if !utils.HasVersionLine(cfg, branch) {
+		return "", fmt.Errorf("'%s' is not named after a version line such as 1.x", branch)
+	}
+
+	var line []string
+	for _, version := range releasedVersions(cfg, "") {
+		if utils.InSupportLine(cfg, branch, version) {
+			line = append(line, version)
+		}
+	}
+
+	latest, ok := latestVersion(cfg, line)
+	if !ok {
+		return "", fmt.Errorf("no release tag found in the line of '%s'", branch)
+	}
+	return utils.GetTagPrefix(cfg) + latest, nil
+}
+
+// versionTaken reports whether version is already tagged or used by a local release or
+// hotfix branch.
+func versionTaken(cfg *utils.Config, version string) bool {
+	if gitutils.TagExists(utils.GetTagPrefix(cfg) + version) {
+		return true
+	}
+	for _, branchType := range []string{utils.TypeRelease, utils.TypeHotfix} {
+		prefix := utils.PrefixForType(cfg, branchType)
+		if prefix == "" {
+			continue
+		}
+		if gitutils.LocalBranchExists(prefix+version) || gitutils.LocalBranchExists(prefix+utils.GetTagPrefix(cfg)+version) {
+			return true
+		}
+	}
+	return false
+}
+
+// releasedVersions returns the versions of the final release tags, reachable from ref
+// when it is not empty.
+func releasedVersions(cfg *utils.Config, ref string) []string {
+	var tags []string
+	if ref == "" {
+		for _, tag := range gitutils.ListTags() {
+			tags = append(tags, tag.Name)
+		}
+	} else {
+		tags = gitutils.MergedTags(ref)
+	}
+
+	prefix := utils.GetTagPrefix(cfg)
+	var versions []string
+	for _, tag := range tags {
+		if !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		if version := strings.TrimPrefix(tag, prefix); isRelease(cfg, version) {
+			versions = append(versions, version)
+		}
+	}
+	return versions
+}
+
+// latestVersion returns the highest of versions according to the scheme.
+func latestVersion(cfg *utils.Config, versions []string) (string, bool) {
+	latest, found := "", false
+	for _, version := range versions {
+		if !found || compareVersions(cfg, version, latest) > 0 {
+			latest, found = version, true
+		}
+	}
+	return latest, found
+}
+
+// isRelease reports whether version is a final (not pre-release) version of the scheme.
+func isRelease(cfg *utils.Config, version string) bool {
+	switch utils.GetVersionScheme(cfg) {
+	case utils.SchemeCalver:
+		return utils.GetCalverFormat(cfg).Matches(version)
+	case utils.SchemeFree:
+		return version != ""
+	}
+	parsed, err := semver.Parse(version)
+	return err == nil && parsed.PreRelease == "" && parsed.Build == ""
+}
+
+// compareVersions compares two versions of the scheme. Free-form names compare as strings.
+func compareVersions(cfg *utils.Config, a string, b string) int {
+	switch utils.GetVersionScheme(cfg) {
+	case utils.SchemeCalver:
+		return utils.GetCalverFormat(cfg).Compare(a, b)
+	case utils.SchemeFree:
+		return strings.Compare(a, b)
+	}
+	return semver.Compare(mustSemver(a), mustSemver(b))
+}
+
+// mustSemver parses a version already known to be valid, 0.0.0 for an empty one.
+func mustSemver(version string) semver.Version {
+	parsed, _ := semver.Parse(version)
+	return parsed
+}
+
+// calverFormatText returns the configured calver format for messages.
+func calverFormatText(cfg *utils.Config) string {
+	if cfg.Versioning.CalverFormat != "" {
+		return cfg.Versioning.CalverFormat
+	}
+	return utils.DefaultCalverFormat
+}
@@ -0,0 +1,164 @@
+// Package versioning derives build versions from the repository history, GitVersion-style:
+// the latest reachable version tag, the rule of the current branch (see
+// `versioning.rules`) and the number of commits since the tag. Build versions are
+// semantic versions: they are computed with the semver scheme only.
+package versioning
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
+	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/semver"
+)
+
+// labelUnsafe matches the characters not allowed in a pre-release identifier.
+var labelUnsafe = regexp.MustCompile(`[^0-9A-Za-z-]+`)
+
+// Result is a computed version and the data it was derived from.
+type Result struct {
+	Version    string `json:"version"` // full semantic version, e.g. 1.3.0-alpha.feature-login.5
+	Major      int    `json:"major"`
+	Minor      int    `json:"minor"`
+	Patch      int    `json:"patch"`
+	PreRelease string `json:"prerelease"` // e.g. rc.2, empty for release versions
+	Build      string `json:"build"`      // build metadata, the commit distance on untagged release versions
+	Tag        string `json:"tag"`        // Version with the tag prefix
+	Branch     string `json:"branch"`     // branch the version was computed for
+	Rule       string `json:"rule"`       // `versioning.rules` key applied to the branch
+	BaseTag    string `json:"base_tag"`   // latest version tag reachable from the commit
+	Distance   int    `json:"distance"`   // commits since BaseTag (since the root when there is none)
+	Commit     string `json:"commit"`     // full hash of the commit
+}
+
+// LatestTag returns the highest version tag reachable from ref, by semantic version
+// precedence, and its parsed version. Tags that do not match the tag pattern or are not
+// semantic versions are ignored; see Compute for the other schemes.
+func LatestTag(cfg *utils.Config, ref string) (string, semver.Version, bool) {
+	pattern, err := regexp.Compile(utils.GetTagPattern(cfg))
+	if err != nil {
+		return "", semver.Version{}, false
+	}
+	prefix := utils.GetTagPrefix(cfg)
+
+	latest, found := "", false
+	var latestVersion semver.Version
+	for _, tag := range gitutils.MergedTags(ref) {
+		if !pattern.MatchString(tag) || !strings.HasPrefix(tag, prefix) {
+			continue
+		}
+		version, err := semver.Parse(strings.TrimPrefix(tag, prefix))
+		if err != nil {
+			continue
+		}
+		if !found || semver.Compare(version, latestVersion) > 0 {
+			latest, latestVersion, found = tag, version, true
+		}
+	}
+	return latest, latestVersion, found
+}
+
+// Compute derives the version of ref, built from branch.
+//
+// A commit carrying the latest tag gets that exact version. Otherwise the tag is bumped
+// by the rule's increment (release and hotfix branches named after a version, such as
+// release/1.3.0, use that version instead), and labeled with the rendered label and the
+// commit distance: 1.3.0-alpha.feature-login.5 or 1.3.0-rc.2. Rules without a label
+// produce release versions with the distance as build metadata (1.2.1+3).
+//
+// Increments and pre-release labels only exist for semantic versions, so an error is
+// returned when `versioning.scheme` is calver or free.
+func Compute(cfg *utils.Config, branch string, ref string) (Result, error) {
+	if scheme := utils.GetVersionScheme(cfg); scheme != utils.SchemeSemver {
+		return Result{}, fmt.Errorf("❌ build versions are computed for the semver scheme only, not '%s' (versioning.scheme)", scheme)
+	}
+
+	commit := gitutils.HeadCommit(ref)
+	if commit == "" {
+		return Result{}, fmt.Errorf("❌ '%s' is not a valid commit", ref)
+	}
+
+	key := utils.VersionRuleKey(cfg, branch)
+	rule := utils.GetVersionRule(cfg, key)
+
+	baseTag, base, found := LatestTag(cfg, ref)
+	distance := gitutils.CountCommits(ref)
+	if found {
+		distance = gitutils.CommitCount(baseTag, ref)
+	}
+
+	version := base
+	if !found || distance > 0 {
+		next, err := base.Bump(rule.Increment)
+		if err != nil {
+			return Result{}, fmt.Errorf("❌ versioning.rules.%s: %w", key, err)
+		}
+		if name, ok := versionFromBranch(cfg, branch, key); ok {
+			next = mustSemver(name)
+		}
+		version = next
+
+		if rule.Label != "" {
+			label := renderLabel(rule.Label, branch, key)
+			version.PreRelease = fmt.Sprintf("%s.%d", label, distance+preReleaseNumber(base, next, label))
+		} else if distance > 0 {
+			version.Build = strconv.Itoa(distance)
+		}
+	}
+
+	return Result{
+		Version:    version.String(),
+		Major:      version.Major,
+		Minor:      version.Minor,
+		Patch:      version.Patch,
+		PreRelease: version.PreRelease,
+		Build:      version.Build,
+		Tag:        utils.GetTagPrefix(cfg) + version.String(),
+		Branch:     branch,
+		Rule:       key,
+		BaseTag:    baseTag,
+		Distance:   distance,
+		Commit:     commit,
+	}, nil
+}
+
+// versionFromBranch returns the version in the name of release and hotfix branches, e.g.
+// 1.3.0 for release/1.3.0 or hotfix/v1.2.1, when it is a release of the versioning scheme.
+func versionFromBranch(cfg *utils.Config, branch string, key string) (string, bool) {
+	if key != utils.TypeRelease && key != utils.TypeHotfix {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(branch, utils.PrefixForType(cfg, key))
+	name = strings.TrimPrefix(name, utils.GetTagPrefix(cfg))
+	if !isRelease(cfg, name) {
+		return "", false
+	}
+	return name, true
+}
+
+// preReleaseNumber returns the number of the base tag when it is a pre-release of next
+// with the same label (e.g. v1.3.0-rc.1 for 1.3.0-rc), so numbering continues after it.
+func preReleaseNumber(base semver.Version, next semver.Version, label string) int {
+	if base.PreRelease == "" || semver.Compare(base.Core(), next) != 0 {
+		return 0
+	}
+	number, err := strconv.Atoi(strings.TrimPrefix(base.PreRelease, label+"."))
+	if err != nil || !strings.HasPrefix(base.PreRelease, label+".") {
+		return 0
+	}
+	return number
+}
+
+// renderLabel replaces {branch} and {type} in a label template. Characters that are not
+// allowed in pre-release identifiers become '-' (feature/login → feature-login).
+func renderLabel(template string, branch string, key string) string {
+	safeBranch := strings.Trim(labelUnsafe.ReplaceAllString(branch, "-"), "-")
+	if safeBranch == "" {
+		safeBranch = "detached"
+	}
+	return strings.NewReplacer("{branch}", safeBranch, "{type}", key).Replace(template)
+}
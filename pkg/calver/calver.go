@@ -0,0 +1,170 @@
+// Package calver implements calendar versions (https://calver.org) such as 2026.10.1.
+//
+// A format is a dot-separated list of tokens:
+//
+//	YYYY  full year          2026
+//	YY    short year         26 (6 in 2006)
+//	0Y    zero-padded year   26 (06 in 2006)
+//	MM    month              1 ... 12
+//	0M    zero-padded month  01 ... 12
+//	WW    ISO week           1 ... 53
+//	0W    zero-padded week   01 ... 53
+//	DD    day                1 ... 31
+//	0D    zero-padded day    01 ... 31
+//	MICRO release counter within the period, starting at 0
+package calver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Micro is the token of the release counter.
+const Micro = "MICRO"
+
+// tokenPatterns maps each token to the POSIX extended regexp matching it.
+var tokenPatterns = map[string]string{
+	"YYYY": "[0-9]{4}",
+	"YY":   "(0|[1-9][0-9]?)",
+	"0Y":   "[0-9]{2}",
+	"MM":   "(1[0-2]|[1-9])",
+	"0M":   "(0[1-9]|1[0-2])",
+	"WW":   "(5[0-3]|[1-4][0-9]|[1-9])",
+	"0W":   "(0[1-9]|[1-4][0-9]|5[0-3])",
+	"DD":   "(3[01]|[12][0-9]|[1-9])",
+	"0D":   "(0[1-9]|[12][0-9]|3[01])",
+	Micro:  "(0|[1-9][0-9]*)",
+}
+
+// Format is a parsed calendar version format.
+type Format struct {
+	tokens []string
+	re     *regexp.Regexp
+}
+
+// ParseFormat parses a format such as "YYYY.MM.MICRO". MICRO, if present, must be the last token.
+func ParseFormat(format string) (Format, error) {
+	tokens := strings.Split(format, ".")
+	for i, token := range tokens {
+		if _, ok := tokenPatterns[token]; !ok {
+			return Format{}, fmt.Errorf("unknown calver token '%s' in '%s'", token, format)
+		}
+		if token == Micro && i != len(tokens)-1 {
+			return Format{}, fmt.Errorf("%s must be the last token of '%s'", Micro, format)
+		}
+	}
+
+	f := Format{tokens: tokens}
+	f.re = regexp.MustCompile("^" + f.Pattern() + "$")
+	return f, nil
+}
+
+// Pattern returns the regexp matching versions of the format, without anchors. It is
+// valid both as a Go regexp and as a POSIX extended regexp.
+func (f Format) Pattern() string {
+	parts := make([]string, len(f.tokens))
+	for i, token := range f.tokens {
+		parts[i] = tokenPatterns[token]
+	}
+	return strings.Join(parts, `\.`)
+}
+
+// HasMicro reports whether the format has a release counter.
+func (f Format) HasMicro() bool {
+	return f.tokens[len(f.tokens)-1] == Micro
+}
+
+// Matches reports whether version follows the format.
+func (f Format) Matches(version string) bool {
+	return f.re.MatchString(version)
+}
+
+// Compare returns -1, 0 or 1 depending on whether version a is older, equal or newer than
+// b. Both must match the format.
+func (f Format) Compare(a string, b string) int {
+	left, right := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		x, _ := strconv.Atoi(left[i])
+		y, _ := strconv.Atoi(right[i])
+		switch {
+		case x < y:
+			return -1
+		case x > y:
+			return 1
+		}
+	}
+	return 0
+}
+
+// Next returns the version of a release made at now, given the existing versions: the
+// date of now, with a MICRO one higher than the latest release of the same period.
+func (f Format) Next(now time.Time, existing []string) (string, error) {
+	period := f.period(now)
+	micro := -1
+	for _, version := range existing {
+		if !f.Matches(version) {
+			continue
+		}
+		if !f.HasMicro() && version == period {
+			return "", fmt.Errorf("%s is already released and the format has no %s", version, Micro)
+		}
+		if f.HasMicro() && strings.HasPrefix(version, period+".") {
+			n, _ := strconv.Atoi(strings.TrimPrefix(version, period+"."))
+			if n > micro {
+				micro = n
+			}
+		}
+	}
+
+	if !f.HasMicro() {
+		return period, nil
+	}
+	return fmt.Sprintf("%s.%d", period, micro+1), nil
+}
+
+// BumpMicro returns the version following version in the same period, e.g. the hotfix
+// 2026.10.2 of 2026.10.1.
+func (f Format) BumpMicro(version string) (string, error) {
+	if !f.Matches(version) {
+		return "", fmt.Errorf("'%s' does not follow the calver format", version)
+	}
+	if !f.HasMicro() {
+		return "", fmt.Errorf("the calver format has no %s to increment", Micro)
+	}
+
+	i := strings.LastIndex(version, ".")
+	micro, _ := strconv.Atoi(version[i+1:])
+	return fmt.Sprintf("%s.%d", version[:i], micro+1), nil
+}
+
+// period renders the date tokens of the format (everything but MICRO) for t.
+func (f Format) period(t time.Time) string {
+	_, week := t.ISOWeek()
+	var parts []string
+	for _, token := range f.tokens {
+		switch token {
+		case "YYYY":
+			parts = append(parts, strconv.Itoa(t.Year()))
+		case "YY":
+			parts = append(parts, strconv.Itoa(t.Year()%100))
+		case "0Y":
+			parts = append(parts, fmt.Sprintf("%02d", t.Year()%100))
+		case "MM":
+			parts = append(parts, strconv.Itoa(int(t.Month())))
+		case "0M":
+			parts = append(parts, fmt.Sprintf("%02d", int(t.Month())))
+		case "WW":
+			parts = append(parts, strconv.Itoa(week))
+		case "0W":
+			parts = append(parts, fmt.Sprintf("%02d", week))
+		case "DD":
+			parts = append(parts, strconv.Itoa(t.Day()))
+		case "0D":
+			parts = append(parts, fmt.Sprintf("%02d", t.Day()))
+		}
+	}
+	return strings.Join(parts, ".")
+}
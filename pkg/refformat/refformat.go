@@ -0,0 +1,88 @@
+// Package refformat implements Git's rules for reference and branch names, as described
+// in git-check-ref-format(1).
+//
+// It has no dependencies on the rest of dflow so that both the configuration loader and
+// the command validators can share a single implementation.
+package refformat
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// forbiddenChars are the printable ASCII characters Git never accepts in ref names.
+const forbiddenChars = " ~^:?*[\\"
+
+// CheckBranchName returns an error describing why name is not a valid branch name,
+// or nil if Git would accept it.
+//
+// It applies every rule of `git check-ref-format --allow-onelevel`, plus the extra rules
+// Git enforces for branches: a name cannot start with '-' and cannot be "HEAD".
+func CheckBranchName(name string) error {
+	if name == "" {
+		return errors.New("branch name is empty")
+	}
+	if name == "@" {
+		return errors.New("branch name cannot be the single character '@'")
+	}
+	if name == "HEAD" {
+		return errors.New("branch name cannot be 'HEAD'")
+	}
+	if strings.HasPrefix(name, "-") {
+		return errors.New("branch name cannot start with '-'")
+	}
+	if strings.HasPrefix(name, "/") {
+		return errors.New("branch name cannot start with '/'")
+	}
+	if strings.HasSuffix(name, "/") {
+		return errors.New("branch name cannot end with '/'")
+	}
+	if strings.HasSuffix(name, ".") {
+		return errors.New("branch name cannot end with '.'")
+	}
+
+	for _, sequence := range []string{"..", "@{", "//"} {
+		if strings.Contains(name, sequence) {
+			return fmt.Errorf("branch name cannot contain '%s'", sequence)
+		}
+	}
+
+	for i := 0; i < len(name); i++ {
+		c := name[i]
+		if c < 0x20 || c == 0x7f {
+			return errors.New("branch name contains control characters")
+		}
+		if c == ' ' {
+			return errors.New("branch name cannot contain spaces")
+		}
+		if strings.IndexByte(forbiddenChars, c) >= 0 {
+			return fmt.Errorf("branch name cannot contain '%c'", c)
+		}
+	}
+
+	for _, component := range strings.Split(name, "/") {
+		if strings.HasPrefix(component, ".") {
+			return fmt.Errorf("path component '%s' cannot start with '.'", component)
+		}
+		if strings.HasSuffix(component, ".lock") {
+			return fmt.Errorf("path component '%s' cannot end with '.lock'", component)
+		}
+	}
+
+	return nil
+}
+
+// CheckBranchPrefix returns an error if prefix cannot start a valid branch name.
+//
+// Prefixes such as "feature/" are incomplete names, so they are checked as if a
+// name followed them. An empty prefix is valid.
+func CheckBranchPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if err := CheckBranchName(prefix + "x"); err != nil {
+		return fmt.Errorf("prefix '%s' is invalid: %w", prefix, err)
+	}
+	return nil
+}
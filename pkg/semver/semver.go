@@ -0,0 +1,162 @@
+// Package semver parses, compares and increments semantic versions (https://semver.org).
+//
+// Versions are written without a prefix ("1.2.0-rc.1+build.5"); callers strip tag
+// prefixes such as "v" before parsing.
+package semver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Increments accepted by Bump.
+const (
+	Major = "major"
+	Minor = "minor"
+	Patch = "patch"
+	None  = "none"
+)
+
+// Increments lists the valid arguments of Bump.
+var Increments = []string{Major, Minor, Patch, None}
+
+// versionRe matches a semantic version, capturing core numbers, pre-release and build.
+var versionRe = regexp.MustCompile(`^(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)` +
+	`(?:-((?:0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*)(?:\.(?:0|[1-9][0-9]*|[0-9]*[A-Za-z-][0-9A-Za-z-]*))*))?` +
+	`(?:\+([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?$`)
+
+// Version is a parsed semantic version.
+type Version struct {
+	Major      int
+	Minor      int
+	Patch      int
+	PreRelease string // dot-separated identifiers after '-', e.g. "rc.1"
+	Build      string // dot-separated identifiers after '+', ignored for precedence
+}
+
+// Parse parses a semantic version such as "1.2.0" or "1.3.0-rc.1+5".
+func Parse(text string) (Version, error) {
+	match := versionRe.FindStringSubmatch(text)
+	if match == nil {
+		return Version{}, fmt.Errorf("'%s' is not a semantic version", text)
+	}
+
+	var v Version
+	v.Major, _ = strconv.Atoi(match[1])
+	v.Minor, _ = strconv.Atoi(match[2])
+	v.Patch, _ = strconv.Atoi(match[3])
+	v.PreRelease = match[4]
+	v.Build = match[5]
+	return v, nil
+}
+
+// String formats the version.
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
+}
+
+// Core returns the version without pre-release and build metadata.
+func (v Version) Core() Version {
+	return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// Bump returns the next release version for the given increment. A pre-release is
+// released as its core version (1.3.0-rc.1 → 1.3.0) unless a larger part is bumped.
+func (v Version) Bump(increment string) (Version, error) {
+	if !IsValidIncrement(increment) {
+		return v, fmt.Errorf("unknown increment '%s' (use %s)", increment, strings.Join(Increments, ", "))
+	}
+
+	next := v.Core()
+	if v.PreRelease != "" {
+		// 2.0.0-rc.1 already is the next major, 1.3.0-rc.1 the next minor
+		crossesMajor := increment == Major && (v.Minor != 0 || v.Patch != 0)
+		crossesMinor := increment == Minor && v.Patch != 0
+		if !crossesMajor && !crossesMinor {
+			return next, nil
+		}
+	}
+
+	switch increment {
+	case Major:
+		next = Version{Major: v.Major + 1}
+	case Minor:
+		next = Version{Major: v.Major, Minor: v.Minor + 1}
+	case Patch:
+		next.Patch++
+	}
+	return next, nil
+}
+
+// Compare returns -1, 0 or 1 depending on whether a has lower, equal or higher
+// precedence than b. Build metadata is ignored.
+func Compare(a Version, b Version) int {
+	for _, pair := range [][2]int{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if pair[0] != pair[1] {
+			return sign(pair[0] - pair[1])
+		}
+	}
+
+	// a version without pre-release has higher precedence than one with it
+	switch {
+	case a.PreRelease == b.PreRelease:
+		return 0
+	case a.PreRelease == "":
+		return 1
+	case b.PreRelease == "":
+		return -1
+	}
+
+	left, right := strings.Split(a.PreRelease, "."), strings.Split(b.PreRelease, ".")
+	for i := 0; i < len(left) && i < len(right); i++ {
+		if c := compareIdentifier(left[i], right[i]); c != 0 {
+			return c
+		}
+	}
+	return sign(len(left) - len(right))
+}
+
+// IsValidIncrement reports whether increment is accepted by Bump.
+func IsValidIncrement(increment string) bool {
+	for _, valid := range Increments {
+		if increment == valid {
+			return true
+		}
+	}
+	return false
+}
+
+// compareIdentifier compares pre-release identifiers: numeric ones numerically and lower
+// than alphanumeric ones, which compare in ASCII order.
+func compareIdentifier(a string, b string) int {
+	x, errA := strconv.Atoi(a)
+	y, errB := strconv.Atoi(b)
+	switch {
+	case errA == nil && errB == nil:
+		return sign(x - y)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func sign(n int) int {
+	switch {
+	case n < 0:
+		return -1
+	case n > 0:
+		return 1
+	}
+	return 0
+}
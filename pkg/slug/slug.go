@@ -0,0 +1,120 @@
+// Package slug turns free text into names that are safe to use in Git branches.
+//
+// It transliterates accented and special Latin characters to ASCII, lowercases the
+// result, replaces punctuation and whitespace with a single separator and can truncate
+// the output on word boundaries. Dots between letters or digits are kept so that
+// version-like names (e.g. "v1.2.0") survive normalization.
+package slug
+
+import (
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// Separator joins the words of a slug.
+const Separator = '-'
+
+// Options controls how Make normalizes text.
+type Options struct {
+	KeepUnicode bool // keep non-ASCII letters instead of transliterating them
+	MaxLength   int  // maximum length in characters (0 = no limit)
+}
+
+// transliterations covers Latin letters that do not decompose into an ASCII base
+// letter plus combining marks.
+var transliterations = map[rune]string{
+	'ß': "ss", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+	'ø': "o", 'Ø': "O", 'ł': "l", 'Ł': "L", 'đ': "d", 'Đ': "D",
+	'ð': "d", 'Ð': "D", 'þ': "th", 'Þ': "TH", 'ı': "i", 'ħ': "h", 'Ħ': "H",
+}
+
+// Make converts text into a slug.
+//
+// Example:
+//
+//	slug.Make("Añadir pantalla de éxito!", slug.Options{}) // "anadir-pantalla-de-exito"
+func Make(text string, opts Options) string {
+	if !opts.KeepUnicode {
+		text = transliterate(text)
+	}
+	text = strings.ToLower(text)
+
+	var b strings.Builder
+	pendingSeparator := false
+	var last rune
+
+	for _, r := range text {
+		switch {
+		case isWordRune(r, opts.KeepUnicode):
+			if pendingSeparator && b.Len() > 0 {
+				b.WriteRune(Separator)
+			}
+			pendingSeparator = false
+			b.WriteRune(r)
+			last = r
+		case r == '.' && !pendingSeparator && b.Len() > 0 && last != '.':
+			b.WriteRune(r)
+			last = r
+		default:
+			pendingSeparator = true
+		}
+
+		// a dot followed by a separator is dropped ("v1. beta" -> "v1-beta")
+		if pendingSeparator && last == '.' {
+			trimmed := strings.TrimRight(b.String(), ".")
+			b.Reset()
+			b.WriteString(trimmed)
+			last = 0
+		}
+	}
+
+	result := strings.Trim(b.String(), ".-")
+	if opts.MaxLength > 0 {
+		result = truncate(result, opts.MaxLength)
+	}
+	return result
+}
+
+// transliterate maps text to ASCII where possible by decomposing characters and
+// removing combining marks (é -> e) and by replacing special letters (ß -> ss).
+func transliterate(text string) string {
+	var b strings.Builder
+	for _, r := range norm.NFD.String(text) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if replacement, ok := transliterations[r]; ok {
+			b.WriteString(replacement)
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// isWordRune reports whether r is kept as part of a word.
+func isWordRune(r rune, keepUnicode bool) bool {
+	if r < unicode.MaxASCII {
+		return (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9')
+	}
+	return keepUnicode && (unicode.IsLetter(r) || unicode.IsDigit(r))
+}
+
+// truncate shortens s to at most max characters, cutting at the last word boundary
+// when possible.
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+
+	cut := string(runes[:max])
+	if runes[max] != Separator {
+		if i := strings.LastIndexByte(cut, byte(Separator)); i > 0 {
+			cut = cut[:i]
+		}
+	}
+	return strings.Trim(cut, ".-")
+}
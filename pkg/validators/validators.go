@@ -1,17 +1,21 @@
 // Package validators provides pre-execution checks for dflow commands.
 //
-// These checks ensure that commands are executed within a Git repository
-// and that the project has been initialized with a .dflow.yaml file.
+// These checks ensure that commands are executed within a Git repository,
+// that the project has been initialized with a .dflow.yaml file and that the
+// repository is in a state where branches can be safely switched.
 package validators
 
 import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/yepizrene-devoost/dflow/cmd/gitutils"
 	"github.com/yepizrene-devoost/dflow/cmd/utils"
+	"github.com/yepizrene-devoost/dflow/pkg/refformat"
 )
 
 // EnsureGitRepo returns an error if the current directory is not a Git repository.
@@ -34,6 +38,25 @@ func EnsureDflowInitialized() error {
 	return nil
 }
 
+// EnsureOnBranch returns an error if HEAD is detached.
+//
+// Commands that create or integrate branches need a checked-out branch to start from.
+func EnsureOnBranch() error {
+	if gitutils.IsDetachedHead() {
+		return errors.New("HEAD is detached. Check out a branch before running this command")
+	}
+	return nil
+}
+
+// EnsureNoOperationInProgress returns an error if a merge, rebase, cherry-pick or revert
+// has been started and not yet completed.
+func EnsureNoOperationInProgress() error {
+	if op := gitutils.OperationInProgress(); op != "" {
+		return fmt.Errorf("a %s is in progress. Finish or abort it before running this command", op)
+	}
+	return nil
+}
+
 // WithChecks wraps a Cobra command handler function (`RunE`) with repository and config validations.
 //
 // If `skipDflowCheck` is false, it verifies that `.dflow.yaml` exists.
@@ -63,61 +86,209 @@ func WithChecks(skipDflowCheck bool, fn func(cmd *cobra.Command, args []string)
 
 // IsValidGitBranchName checks whether a given branch name is valid according to Git's reference format rules.
 //
-// A branch name is considered invalid if it contains any of the following:
-//   - Double dots ("..")
-//   - Special characters such as ~, ^, :, ?, *, [, \, or the sequence "@{"
-//   - Starts with a dash ("-")
-//   - Ends with a slash ("/"), a dot ("."), or ".lock"
-//   - Is an empty string
+// It implements every rule from `git-check-ref-format(1)` for branch names (see package
+// refformat), including:
+//   - No path component starting with '.' or ending with ".lock"
+//   - No double dots (".."), "@{" sequences or empty components ("//")
+//   - No spaces, control characters or any of ~ ^ : ? * [ \
+//   - Cannot start or end with '/', end with '.', start with '-', or be "@" or "HEAD"
 //
-// These checks are based on Git's rules for ref names described in `git-check-ref-format(1)`.
-//
-// Returns true if the branch name is valid, false otherwise.
+// Returns true if the branch name is valid, false and the reason otherwise.
 func IsValidGitBranchName(name string) (bool, string) {
-	if name == "" {
-		return false, "branch name is empty"
+	if err := refformat.CheckBranchName(name); err != nil {
+		return false, err.Error()
 	}
+	return true, ""
+}
 
-	if strings.HasPrefix(name, "-") {
-		return false, "branch name cannot start with '-'"
+// ValidateTicket checks a ticket ID against the rule's ticket pattern.
+//
+// The pattern must match the whole ticket. An empty pattern accepts any non-empty ticket.
+func ValidateTicket(rule utils.NamingRule, ticket string) error {
+	if ticket == "" {
+		return errors.New("a ticket ID is required")
 	}
-	if strings.HasPrefix(name, "/") {
-		return false, "branch name cannot start with '/'"
+	if rule.TicketPattern == "" {
+		return nil
 	}
-	if strings.HasSuffix(name, "/") {
-		return false, "branch name cannot end with '/'"
+
+	matched, err := matchWhole(rule.TicketPattern, ticket)
+	if err != nil {
+		return fmt.Errorf("invalid ticket_pattern '%s': %w", rule.TicketPattern, err)
 	}
-	if strings.HasSuffix(name, ".") {
-		return false, "branch name cannot end with '.'"
+	if !matched {
+		return fmt.Errorf("ticket '%s' does not match the required pattern '%s'", ticket, rule.TicketPattern)
 	}
-	if strings.HasSuffix(name, ".lock") {
-		return false, "branch name cannot end with '.lock'"
+	return nil
+}
+
+// ValidateBranchPolicy enforces a naming rule on a rendered branch name.
+//
+// It checks that no placeholder is left unexpanded, that the ticket (when the template
+// requires one) matches the ticket pattern, that slug matches the rule's pattern and
+// that name does not exceed the maximum length.
+func ValidateBranchPolicy(rule utils.NamingRule, name string, slug string, ticket string) error {
+	if i := strings.Index(name, "{"); i >= 0 && strings.Contains(name[i:], "}") {
+		return fmt.Errorf("branch name '%s' contains an unknown placeholder", name)
 	}
 
-	invalidPatterns := []string{"..", "~", "^", ":", "?", "*", "[", "\\", "@{"}
-	for _, pattern := range invalidPatterns {
-		if strings.Contains(name, pattern) {
-			return false, fmt.Sprintf("branch name cannot contain '%s'", pattern)
+	if rule.RequiresTicket() {
+		if err := ValidateTicket(rule, ticket); err != nil {
+			return err
 		}
 	}
 
-	// Component-level checks (split by '/')
-	parts := strings.Split(name, "/")
-	for _, part := range parts {
-		if part == "." || part == ".." {
-			return false, "branch name cannot contain path elements '.' or '..'"
+	if rule.Pattern != "" {
+		matched, err := matchWhole(rule.Pattern, slug)
+		if err != nil {
+			return fmt.Errorf("invalid naming pattern '%s': %w", rule.Pattern, err)
 		}
-		if part == "" {
-			return false, "branch name cannot contain empty path segments ('//')"
+		if !matched {
+			return fmt.Errorf("name '%s' does not match the required pattern '%s'", slug, rule.Pattern)
 		}
 	}
 
-	// Control characters (ASCII 0-31) and DEL (127)
-	for i := 0; i < len(name); i++ {
-		if name[i] < 32 || name[i] == 127 {
-			return false, "branch name contains control characters"
+	if rule.MaxLength > 0 && len(name) > rule.MaxLength {
+		return fmt.Errorf("branch name '%s' is %d characters long (maximum %d)", name, len(name), rule.MaxLength)
+	}
+
+	return nil
+}
+
+// ValidateCommitMessage checks the subject (first non-comment line) of a commit message
+// against pattern (see utils.GetCommitPattern).
+//
+// Messages generated by Git for merges and reverts, and fixup!/squash!/amend! commits
+// meant to be autosquashed, are always accepted.
+func ValidateCommitMessage(pattern string, message string) error {
+	subject := ""
+	for _, line := range strings.Split(message, "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
+		subject = line
+		break
 	}
 
-	return true, ""
+	if subject == "" {
+		return errors.New("the commit message is empty")
+	}
+
+	for _, prefix := range []string{"Merge ", "Revert \"", "fixup! ", "squash! ", "amend! "} {
+		if strings.HasPrefix(subject, prefix) {
+			return nil
+		}
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid commit pattern '%s': %w", pattern, err)
+	}
+	if !re.MatchString(subject) {
+		return fmt.Errorf("commit subject '%s' does not follow the convention '%s'", subject, pattern)
+	}
+	return nil
+}
+
+// ValidatePush checks whether branch may be pushed directly according to the flow:
+// base branches and branches listed in `workflow.branch_rules` whose merge mode is
+// manual only change through pull requests, and any other branch must start with one
+// of the configured type prefixes.
+func ValidatePush(cfg *utils.Config, branch string) error {
+	_, ruled := cfg.Workflow.BranchRules[branch]
+	if ruled || utils.IsProtectedBranch(cfg, branch) {
+		if utils.GetMergeModeForBranch(cfg, branch) == "manual" {
+			return fmt.Errorf("'%s' uses manual merges: changes must go through a pull request", branch)
+		}
+		return nil
+	}
+
+	if utils.BranchTypeFor(cfg, branch) == "" {
+		return fmt.Errorf("'%s' does not start with a configured prefix (%s)", branch, strings.Join(configuredPrefixes(cfg), ", "))
+	}
+	return nil
+}
+
+// ValidatePullRequest checks that a pull request from source into target follows the flow:
+// feature branches target `flow.feature_merge` (or `flow.feature_base`), bugfixes
+// `flow.bugfix_base`, hotfixes `flow.hotfix_base` (or a support branch), releases the main
+// branch and backports the target in their name. Pull requests between two different base
+// branches (e.g. develop into uat) are accepted.
+func ValidatePullRequest(cfg *utils.Config, source string, target string) error {
+	if source == target {
+		return fmt.Errorf("source and target are both '%s'", source)
+	}
+
+	branchType := utils.BranchTypeFor(cfg, source)
+	if branchType == "" {
+		if utils.IsProtectedBranch(cfg, source) {
+			if !utils.IsProtectedBranch(cfg, target) {
+				return fmt.Errorf("base branch '%s' can only be merged into another base branch (%s), not '%s'",
+					source, strings.Join(utils.BaseBranches(cfg), ", "), target)
+			}
+			return nil
+		}
+		return fmt.Errorf("'%s' is not a base branch and does not start with a configured prefix (%s)",
+			source, strings.Join(configuredPrefixes(cfg), ", "))
+	}
+
+	expected, key := pullRequestTarget(cfg, branchType)
+	switch branchType {
+	case utils.TypeHotfix:
+		// hotfixes of an older version line target its support branch
+		if utils.BranchTypeFor(cfg, target) == utils.TypeSupport {
+			return nil
+		}
+	case utils.TypeBackport:
+		expected, key = utils.BackportTarget(cfg, source), "the target in the backport branch name"
+	case utils.TypeSupport:
+		return fmt.Errorf("support branches are long-lived and are not merged, but '%s' targets '%s'", source, target)
+	}
+	if expected == "" {
+		return fmt.Errorf("no merge target is configured for %s branches (%s)", branchType, key)
+	}
+	if target != expected {
+		return fmt.Errorf("%s branches must target '%s' (%s), but '%s' targets '%s'", branchType, expected, key, source, target)
+	}
+	return nil
+}
+
+// pullRequestTarget returns the branch pull requests of branchType must target and the
+// configuration key it comes from.
+func pullRequestTarget(cfg *utils.Config, branchType string) (string, string) {
+	switch branchType {
+	case utils.TypeFeature:
+		if cfg.Flow.FeatureMerge != "" {
+			return cfg.Flow.FeatureMerge, "flow.feature_merge"
+		}
+		return cfg.Flow.FeatureBase, "flow.feature_base"
+	case utils.TypeBugfix:
+		return cfg.Flow.BugfixBase, "flow.bugfix_base"
+	case utils.TypeHotfix:
+		return cfg.Flow.HotfixBase, "flow.hotfix_base"
+	case utils.TypeRelease:
+		return cfg.Branches.Main, "branches.main"
+	}
+	return "", ""
+}
+
+// configuredPrefixes returns the non-empty branch prefixes of the configuration.
+func configuredPrefixes(cfg *utils.Config) []string {
+	var prefixes []string
+	for _, branchType := range utils.BranchTypes {
+		if prefix := utils.PrefixForType(cfg, branchType); prefix != "" {
+			prefixes = append(prefixes, prefix)
+		}
+	}
+	return prefixes
+}
+
+// matchWhole reports whether pattern matches the entire value.
+func matchWhole(pattern string, value string) (bool, error) {
+	re, err := regexp.Compile("^(?:" + pattern + ")$")
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(value), nil
 }